@@ -0,0 +1,412 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+// Package accountsync keeps the subscriptions, playlists and watch history of
+// two Invidious accounts consistent.
+//
+// The state of the last synchronization is stored in a file, so that an item
+// missing from one side can be told apart as added on the other side or
+// deleted on this one.
+package accountsync
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/antoniszymanski/invidious-go"
+	"github.com/antoniszymanski/invidious-go/playlists"
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+type Side uint8
+
+const (
+	Primary Side = iota + 1
+	Secondary
+)
+
+func (s Side) String() string {
+	if s == Primary {
+		return "primary"
+	}
+	return "secondary"
+}
+
+func (s Side) other() Side {
+	if s == Primary {
+		return Secondary
+	}
+	return Primary
+}
+
+// Strategy resolves playlists changed differently on both sides since the
+// last synchronization.
+type Strategy uint8
+
+const (
+	SkipConflicts   Strategy = iota // report conflicts and leave both sides unchanged
+	PreferPrimary                   // overwrite the secondary side
+	PreferSecondary                 // overwrite the primary side
+)
+
+type Syncer struct {
+	Primary   *invidious.Client
+	Secondary *invidious.Client
+	StateFile string
+	Strategy  Strategy
+
+	SkipSubscriptions bool
+	SkipPlaylists     bool
+	SkipHistory       bool
+}
+
+// State is a snapshot of an account. Playlists are identified by title, since
+// their IDs differ between instances.
+type State struct {
+	Subscriptions []string            `json:"subscriptions"`
+	Playlists     map[string][]string `json:"playlists"`
+	History       []string            `json:"history"` // oldest first
+}
+
+type ActionKind uint8
+
+const (
+	AddSubscription ActionKind = iota + 1
+	RemoveSubscription
+	AddToHistory
+	DeleteFromHistory
+	CreatePlaylist
+	DeletePlaylist
+	ReplacePlaylistVideos
+)
+
+func (k ActionKind) String() string {
+	switch k {
+	case AddSubscription:
+		return "add subscription"
+	case RemoveSubscription:
+		return "remove subscription"
+	case AddToHistory:
+		return "add to history"
+	case DeleteFromHistory:
+		return "delete from history"
+	case CreatePlaylist:
+		return "create playlist"
+	case DeletePlaylist:
+		return "delete playlist"
+	case ReplacePlaylistVideos:
+		return "replace playlist videos"
+	default:
+		return "unknown action"
+	}
+}
+
+type Action struct {
+	Side   Side // the side that is modified
+	Kind   ActionKind
+	Item   string   // channel ID, video ID or playlist title
+	Videos []string // the desired videos of a playlist
+}
+
+type Conflict struct {
+	Playlist  string
+	Primary   []string // nil if deleted
+	Secondary []string // nil if deleted
+}
+
+// Plan is the diff computed by a synchronization.
+type Plan struct {
+	Actions   []Action
+	Conflicts []Conflict // left unresolved by SkipConflicts
+
+	next      State
+	playlists map[Side]map[string]string // title to ID
+}
+
+func (s *Syncer) LoadState() (*State, error) {
+	var st State
+	data, err := os.ReadFile(s.StateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &st, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+func (s *Syncer) saveState(st *State) error {
+	data, err := json.Marshal(st, jsontext.Multiline(true))
+	if err != nil {
+		return err
+	}
+	tmp := s.StateFile + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.StateFile)
+}
+
+// Plan computes the actions that make both accounts consistent, without
+// applying them.
+func (s *Syncer) Plan(ctx context.Context) (*Plan, error) {
+	base, err := s.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	p := Plan{
+		next:      State{Playlists: make(map[string][]string)},
+		playlists: make(map[Side]map[string]string, 2),
+	}
+	primary, err := p.snapshot(ctx, s, Primary)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := p.snapshot(ctx, s, Secondary)
+	if err != nil {
+		return nil, err
+	}
+
+	// Skipped categories keep their baseline, so that the next full
+	// synchronization still tells additions and deletions apart.
+	if s.SkipSubscriptions {
+		p.next.Subscriptions = base.Subscriptions
+	} else {
+		p.next.Subscriptions = p.mergeSet(base.Subscriptions, primary.Subscriptions, secondary.Subscriptions,
+			AddSubscription, RemoveSubscription)
+	}
+	if s.SkipHistory {
+		p.next.History = base.History
+	} else {
+		p.next.History = p.mergeSet(base.History, primary.History, secondary.History,
+			AddToHistory, DeleteFromHistory)
+	}
+	if s.SkipPlaylists {
+		maps.Copy(p.next.Playlists, base.Playlists)
+	} else {
+		p.mergePlaylists(s.Strategy, base.Playlists, primary.Playlists, secondary.Playlists)
+	}
+	return &p, nil
+}
+
+// Sync computes the plan and applies it. If dryRun is true, it only computes
+// the plan.
+func (s *Syncer) Sync(ctx context.Context, dryRun bool) (*Plan, error) {
+	p, err := s.Plan(ctx)
+	if err != nil || dryRun {
+		return p, err
+	}
+	return p, s.Apply(ctx, p)
+}
+
+// Apply performs the actions of the plan and, if all of them succeed, stores
+// the new state.
+func (s *Syncer) Apply(ctx context.Context, p *Plan) error {
+	var errs []error
+	for _, a := range p.Actions {
+		if err := s.apply(ctx, p, a); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return s.saveState(&p.next)
+}
+
+func (s *Syncer) client(side Side) *invidious.Client {
+	if side == Primary {
+		return s.Primary
+	}
+	return s.Secondary
+}
+
+func (p *Plan) snapshot(ctx context.Context, s *Syncer, side Side) (*State, error) {
+	c := s.client(side).WithContext(ctx)
+	var st State
+	if !s.SkipSubscriptions {
+		subs, err := c.Subscriptions()
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range subs {
+			st.Subscriptions = append(st.Subscriptions, sub.AuthorId)
+		}
+	}
+	if !s.SkipPlaylists {
+		resp, err := c.Playlists()
+		if err != nil {
+			return nil, err
+		}
+		st.Playlists = make(map[string][]string, len(resp))
+		p.playlists[side] = make(map[string]string, len(resp))
+		for _, pl := range resp {
+			full, err := playlists.Fetch(c, pl.PlaylistId)
+			if err != nil {
+				return nil, err
+			}
+			videos := make([]string, len(full.Videos))
+			for i, v := range full.Videos {
+				videos[i] = v.VideoId
+			}
+			st.Playlists[pl.Title] = videos
+			p.playlists[side][pl.Title] = pl.PlaylistId
+		}
+	}
+	if !s.SkipHistory {
+		for id, err := range c.HistoryAll(ctx, invidious.IterOptions{}) {
+			if err != nil {
+				return nil, err
+			}
+			st.History = append(st.History, id)
+		}
+		// Oldest first, so that additions are replayed in watch order.
+		slices.Reverse(st.History)
+	}
+	return &st, nil
+}
+
+// mergeSet performs a three-way merge of a set and returns its new state.
+func (p *Plan) mergeSet(base, primary, secondary []string, add, remove ActionKind) []string {
+	inBase := toSet(base)
+	inPrimary := toSet(primary)
+	inSecondary := toSet(secondary)
+	var next []string
+	for _, item := range union(primary, secondary) {
+		_, a := inPrimary[item]
+		_, b := inSecondary[item]
+		_, wasSynced := inBase[item]
+		switch {
+		case a && b:
+			next = append(next, item)
+		case wasSynced:
+			// Deleted on one side since the last synchronization.
+			side := Primary
+			if !b {
+				side = Secondary
+			}
+			p.Actions = append(p.Actions, Action{Side: side.other(), Kind: remove, Item: item})
+		default:
+			side := Primary
+			if !a {
+				side = Secondary
+			}
+			p.Actions = append(p.Actions, Action{Side: side.other(), Kind: add, Item: item})
+			next = append(next, item)
+		}
+	}
+	return next
+}
+
+func (p *Plan) mergePlaylists(strategy Strategy, base, primary, secondary map[string][]string) {
+	var titles []string
+	for title := range primary {
+		titles = append(titles, title)
+	}
+	for title := range secondary {
+		if _, ok := primary[title]; !ok {
+			titles = append(titles, title)
+		}
+	}
+	slices.Sort(titles)
+
+	for _, title := range titles {
+		a, inPrimary := primary[title]
+		b, inSecondary := secondary[title]
+		old, wasSynced := base[title]
+		changedA := !wasSynced || !inPrimary || !slices.Equal(a, old)
+		changedB := !wasSynced || !inSecondary || !slices.Equal(b, old)
+
+		var winner Side
+		switch {
+		case inPrimary && inSecondary && slices.Equal(a, b):
+			p.next.Playlists[title] = a
+			continue
+		case !wasSynced && !inSecondary, changedA && !changedB:
+			winner = Primary
+		case !wasSynced && !inPrimary, changedB && !changedA:
+			winner = Secondary
+		case strategy == PreferPrimary:
+			winner = Primary
+		case strategy == PreferSecondary:
+			winner = Secondary
+		default:
+			p.Conflicts = append(p.Conflicts, Conflict{Playlist: title, Primary: a, Secondary: b})
+			if wasSynced {
+				p.next.Playlists[title] = old
+			}
+			continue
+		}
+
+		videos, exists := a, inPrimary
+		target, targetExists := Secondary, inSecondary
+		if winner == Secondary {
+			videos, exists = b, inSecondary
+			target, targetExists = Primary, inPrimary
+		}
+		switch {
+		case !exists:
+			p.Actions = append(p.Actions, Action{Side: target, Kind: DeletePlaylist, Item: title})
+		case !targetExists:
+			p.Actions = append(p.Actions, Action{Side: target, Kind: CreatePlaylist, Item: title, Videos: videos})
+			p.next.Playlists[title] = videos
+		default:
+			p.Actions = append(p.Actions, Action{Side: target, Kind: ReplacePlaylistVideos, Item: title, Videos: videos})
+			p.next.Playlists[title] = videos
+		}
+	}
+}
+
+func (s *Syncer) apply(ctx context.Context, p *Plan, a Action) error {
+	c := s.client(a.Side).WithContext(ctx)
+	switch a.Kind {
+	case AddSubscription:
+		return c.AddSubscription(a.Item)
+	case RemoveSubscription:
+		return c.RemoveSubscription(a.Item)
+	case AddToHistory:
+		return c.AddToHistory(a.Item)
+	case DeleteFromHistory:
+		return c.DeleteFromHistory(a.Item)
+	case CreatePlaylist:
+		pl := playlists.Playlist{Title: a.Item, Videos: make([]playlists.Video, len(a.Videos))}
+		for i, id := range a.Videos {
+			pl.Videos[i] = playlists.Video{VideoId: id}
+		}
+		_, err := playlists.Import(ctx, c, &pl, playlists.ImportOptions{})
+		return err
+	case DeletePlaylist:
+		return c.DeletePlaylist(p.playlists[a.Side][a.Item])
+	case ReplacePlaylistVideos:
+		_, err := playlists.SyncPlaylist(ctx, c, p.playlists[a.Side][a.Item], a.Videos, playlists.SyncOptions{})
+		return err
+	default:
+		return errors.New("unknown action: " + a.Kind.String())
+	}
+}
+
+func toSet(items []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		set[item] = struct{}{}
+	}
+	return set
+}
+
+func union(a, b []string) []string {
+	out := slices.Clone(a)
+	inA := toSet(a)
+	for _, item := range b {
+		if _, ok := inA[item]; !ok {
+			out = append(out, item)
+		}
+	}
+	return out
+}
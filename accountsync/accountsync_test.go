@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package accountsync
+
+import (
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/antoniszymanski/invidious-go/invidioustest"
+)
+
+type account struct {
+	subscriptions []string
+	history       []string // oldest first
+	playlists     map[string][]string
+}
+
+func seed(s *invidioustest.Server, a account) {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	for _, id := range a.subscriptions {
+		s.Subscriptions = append(s.Subscriptions, invidioustest.Subscription{Author: id, AuthorId: id})
+	}
+	s.History = slices.Clone(a.history)
+	for title, videos := range a.playlists {
+		pl := &invidioustest.Playlist{Title: title, PlaylistId: "IVPL" + title}
+		for _, id := range videos {
+			pl.Videos = append(pl.Videos, invidioustest.PlaylistVideo{VideoId: id, IndexId: "IX" + id})
+		}
+		s.Playlists = append(s.Playlists, pl)
+	}
+}
+
+func dump(s *invidioustest.Server) account {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	a := account{history: slices.Clone(s.History), playlists: make(map[string][]string)}
+	for _, sub := range s.Subscriptions {
+		a.subscriptions = append(a.subscriptions, sub.AuthorId)
+	}
+	slices.Sort(a.subscriptions)
+	for _, pl := range s.Playlists {
+		var videos []string
+		for _, v := range pl.Videos {
+			videos = append(videos, v.VideoId)
+		}
+		a.playlists[pl.Title] = videos
+	}
+	return a
+}
+
+func assertAccount(t *testing.T, side Side, got, want account) {
+	t.Helper()
+	if !slices.Equal(got.subscriptions, want.subscriptions) {
+		t.Errorf("%v subscriptions = %v, want %v", side, got.subscriptions, want.subscriptions)
+	}
+	if !slices.Equal(got.history, want.history) {
+		t.Errorf("%v history = %v, want %v", side, got.history, want.history)
+	}
+	if len(got.playlists) != len(want.playlists) {
+		t.Errorf("%v playlists = %v, want %v", side, got.playlists, want.playlists)
+	}
+	for title, videos := range want.playlists {
+		if !slices.Equal(got.playlists[title], videos) {
+			t.Errorf("%v playlist %q = %v, want %v", side, title, got.playlists[title], videos)
+		}
+	}
+}
+
+// videoIds returns the IDs v0 to v<n-1>, followed by extra.
+func videoIds(n int, extra ...string) []string {
+	ids := make([]string, n, n+len(extra))
+	for i := range ids {
+		ids[i] = "v" + strconv.Itoa(i)
+	}
+	return append(ids, extra...)
+}
+
+func TestSync(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  Strategy
+		base      *State // nil for the first synchronization
+		primary   account
+		secondary account
+		conflicts int
+		want      [2]account // primary, secondary
+	}{
+		{
+			name: "additions",
+			primary: account{
+				subscriptions: []string{"UCa"},
+				history:       []string{"v1", "v2"},
+				playlists:     map[string][]string{"A": {"v1", "v2"}},
+			},
+			secondary: account{
+				subscriptions: []string{"UCb"},
+				history:       []string{"v3"},
+			},
+			want: [2]account{
+				{
+					subscriptions: []string{"UCa", "UCb"},
+					history:       []string{"v1", "v2", "v3"},
+					playlists:     map[string][]string{"A": {"v1", "v2"}},
+				},
+				{
+					subscriptions: []string{"UCa", "UCb"},
+					history:       []string{"v3", "v1", "v2"},
+					playlists:     map[string][]string{"A": {"v1", "v2"}},
+				},
+			},
+		},
+		{
+			name: "deletions",
+			base: &State{
+				Subscriptions: []string{"UCa", "UCb"},
+				History:       []string{"v1", "v2"},
+				Playlists:     map[string][]string{"A": {"v1"}, "B": {"v2"}},
+			},
+			primary: account{
+				subscriptions: []string{"UCa", "UCb"},
+				history:       []string{"v1", "v2"},
+				playlists:     map[string][]string{"A": {"v1"}, "B": {"v2"}},
+			},
+			secondary: account{
+				subscriptions: []string{"UCa"},
+				history:       []string{"v2"},
+				playlists:     map[string][]string{"A": {"v1"}},
+			},
+			want: [2]account{
+				{subscriptions: []string{"UCa"}, history: []string{"v2"}, playlists: map[string][]string{"A": {"v1"}}},
+				{subscriptions: []string{"UCa"}, history: []string{"v2"}, playlists: map[string][]string{"A": {"v1"}}},
+			},
+		},
+		{
+			name:      "conflict skipped",
+			strategy:  SkipConflicts,
+			base:      &State{Playlists: map[string][]string{"A": {"v1"}}},
+			primary:   account{playlists: map[string][]string{"A": {"v1", "v2"}}},
+			secondary: account{playlists: map[string][]string{"A": {"v3"}}},
+			conflicts: 1,
+			want: [2]account{
+				{playlists: map[string][]string{"A": {"v1", "v2"}}},
+				{playlists: map[string][]string{"A": {"v3"}}},
+			},
+		},
+		{
+			name:      "conflict resolved by primary",
+			strategy:  PreferPrimary,
+			base:      &State{Playlists: map[string][]string{"A": {"v1"}}},
+			primary:   account{playlists: map[string][]string{"A": {"v1", "v2"}}},
+			secondary: account{playlists: map[string][]string{"A": {"v3"}}},
+			want: [2]account{
+				{playlists: map[string][]string{"A": {"v1", "v2"}}},
+				{playlists: map[string][]string{"A": {"v1", "v2"}}},
+			},
+		},
+		{
+			// Invidious returns the videos of a playlist in pages of 100.
+			name:      "large playlist",
+			base:      &State{Playlists: map[string][]string{"A": videoIds(150)}},
+			primary:   account{playlists: map[string][]string{"A": videoIds(150, "new")}},
+			secondary: account{playlists: map[string][]string{"A": videoIds(150)}},
+			want: [2]account{
+				{playlists: map[string][]string{"A": videoIds(150, "new")}},
+				{playlists: map[string][]string{"A": videoIds(150, "new")}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary, secondary := invidioustest.NewServer(), invidioustest.NewServer()
+			defer primary.Close()
+			defer secondary.Close()
+			seed(primary, tt.primary)
+			seed(secondary, tt.secondary)
+			s := &Syncer{
+				Primary:   primary.Client(),
+				Secondary: secondary.Client(),
+				StateFile: filepath.Join(t.TempDir(), "state.json"),
+				Strategy:  tt.strategy,
+			}
+			if tt.base != nil {
+				if err := s.saveState(tt.base); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p, err := s.Sync(t.Context(), false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(p.Conflicts) != tt.conflicts {
+				t.Errorf("conflicts = %v, want %d", p.Conflicts, tt.conflicts)
+			}
+			assertAccount(t, Primary, dump(primary), tt.want[0])
+			assertAccount(t, Secondary, dump(secondary), tt.want[1])
+
+			// The stored state makes the next synchronization a no-op.
+			p, err = s.Plan(t.Context())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(p.Actions) != 0 {
+				t.Errorf("actions after synchronization = %v", p.Actions)
+			}
+		})
+	}
+}
+
+func TestSyncKeepsSkippedBaseline(t *testing.T) {
+	primary, secondary := invidioustest.NewServer(), invidioustest.NewServer()
+	defer primary.Close()
+	defer secondary.Close()
+	seed(primary, account{subscriptions: []string{"UCa"}, history: []string{"v1"}})
+	seed(secondary, account{history: []string{"v1"}})
+	s := &Syncer{
+		Primary:           primary.Client(),
+		Secondary:         secondary.Client(),
+		StateFile:         filepath.Join(t.TempDir(), "state.json"),
+		SkipSubscriptions: true,
+	}
+	base := &State{Subscriptions: []string{"UCa"}, Playlists: map[string][]string{}}
+	if err := s.saveState(base); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Sync(t.Context(), false); err != nil {
+		t.Fatal(err)
+	}
+	st, err := s.LoadState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(st.Subscriptions, base.Subscriptions) {
+		t.Errorf("subscriptions = %v, want %v", st.Subscriptions, base.Subscriptions)
+	}
+	if !slices.Equal(st.History, []string{"v1"}) {
+		t.Errorf("history = %v, want [v1]", st.History)
+	}
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (c *Client) ChannelFeed(req AtomFeedRequest) (*AtomFeedResponse, error) {
+	return c.atomFeed("ChannelFeed", "/feed/channel/"+req.Id, nil, &req)
+}
+
+func (c *Client) PlaylistFeed(req AtomFeedRequest) (*AtomFeedResponse, error) {
+	return c.atomFeed("PlaylistFeed", "/feed/playlist/"+req.Id, nil, &req)
+}
+
+// PrivateFeed fetches the subscription feed of the user whose RSS token is
+// req.Id. The token is shown on the subscription manager page.
+func (c *Client) PrivateFeed(req AtomFeedRequest) (*AtomFeedResponse, error) {
+	query := make(url.Values, 1)
+	query.Set("token", req.Id)
+	return c.atomFeed("PrivateFeed", "/feed/private", query, &req)
+}
+
+type AtomFeedRequest struct {
+	Id string // channel ID, playlist ID, or RSS token
+	// Validators of a previous response. If the feed hasn't changed, the
+	// response has NotModified set and no videos.
+	ETag         string
+	LastModified string
+}
+
+type AtomFeedResponse struct {
+	NotModified  bool
+	ETag         string
+	LastModified string
+
+	Title     string
+	Author    string
+	AuthorId  string // channel ID, for channel feeds
+	AuthorUrl string
+	Published time.Time
+	Videos    []VideoObject
+}
+
+func (c *Client) atomFeed(op, path string, query url.Values, req *AtomFeedRequest) (*AtomFeedResponse, error) {
+	header := make(http.Header, 2)
+	if req.ETag != "" {
+		header.Set("If-None-Match", req.ETag)
+	}
+	if req.LastModified != "" {
+		header.Set("If-Modified-Since", req.LastModified)
+	}
+	var resp AtomFeedResponse
+	if err := c.call(&requestConfig{
+		Operation: op,
+		Method:    "GET",
+		Path:      path,
+		Query:     query,
+		Header:    header,
+		Output:    &resp,
+	}); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+type atomFeed struct {
+	Title     string      `xml:"title"`
+	ChannelId string      `xml:"http://www.youtube.com/xml/schemas/2015 channelId"`
+	Author    atomAuthor  `xml:"author"`
+	Published string      `xml:"published"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	Uri  string `xml:"uri"`
+}
+
+type atomEntry struct {
+	VideoId   string     `xml:"http://www.youtube.com/xml/schemas/2015 videoId"`
+	ChannelId string     `xml:"http://www.youtube.com/xml/schemas/2015 channelId"`
+	Title     string     `xml:"title"`
+	Author    atomAuthor `xml:"author"`
+	Published string     `xml:"published"`
+	Group     struct {
+		Description string `xml:"http://search.yahoo.com/mrss/ description"`
+		Thumbnails  []struct {
+			Url    string `xml:"url,attr"`
+			Width  int64  `xml:"width,attr"`
+			Height int64  `xml:"height,attr"`
+		} `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	} `xml:"http://search.yahoo.com/mrss/ group"`
+	Community struct {
+		Statistics struct {
+			Views string `xml:"views,attr"`
+		} `xml:"http://search.yahoo.com/mrss/ statistics"`
+	} `xml:"http://search.yahoo.com/mrss/ community"`
+}
+
+func (resp *AtomFeedResponse) decodeResponse(r *http.Response) error {
+	resp.ETag = r.Header.Get("ETag")
+	resp.LastModified = r.Header.Get("Last-Modified")
+	if r.StatusCode == http.StatusNotModified {
+		resp.NotModified = true
+		return nil
+	}
+
+	var feed atomFeed
+	if err := xml.NewDecoder(r.Body).Decode(&feed); err != nil {
+		return err
+	}
+	resp.Title = feed.Title
+	resp.Author = feed.Author.Name
+	resp.AuthorUrl = feed.Author.Uri
+	resp.AuthorId = feed.ChannelId
+	resp.Published = parseAtomTime(feed.Published)
+	resp.Videos = make([]VideoObject, len(feed.Entries))
+	for i, e := range feed.Entries {
+		v := VideoObject{
+			Type:        "video",
+			Title:       e.Title,
+			VideoId:     e.VideoId,
+			Author:      e.Author.Name,
+			AuthorId:    e.ChannelId,
+			AuthorUrl:   e.Author.Uri,
+			Description: e.Group.Description,
+			Published:   parseAtomTime(e.Published),
+		}
+		if v.AuthorId == "" {
+			if _, id, ok := strings.Cut(v.AuthorUrl, "/channel/"); ok {
+				v.AuthorId = id
+			}
+		}
+		if views, err := strconv.ParseInt(e.Community.Statistics.Views, 10, 64); err == nil {
+			v.ViewCount = views
+		}
+		for _, t := range e.Group.Thumbnails {
+			v.VideoThumbnails = append(v.VideoThumbnails, ThumbnailObject{
+				Url:    t.Url,
+				Width:  t.Width,
+				Height: t.Height,
+			})
+		}
+		resp.Videos[i] = v
+	}
+	return nil
+}
+
+func parseAtomTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
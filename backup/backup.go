@@ -0,0 +1,258 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+// Package backup snapshots an Invidious account and restores it, possibly on
+// another instance.
+package backup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/antoniszymanski/invidious-go"
+	"github.com/antoniszymanski/invidious-go/playlists"
+	"github.com/antoniszymanski/invidious-go/subscriptions"
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// ArchiveVersion is the version of the archives written by this package.
+const ArchiveVersion = 1
+
+type Archive struct {
+	Version       int                            `json:"version"`
+	Created       time.Time                      `json:"created"`
+	Instance      string                         `json:"instance"`
+	Subscriptions []Subscription                 `json:"subscriptions"`
+	Playlists     []Playlist                     `json:"playlists"`
+	Preferences   *invidious.PreferencesResponse `json:"preferences"`
+	History       []string                       `json:"history"` // newest first
+}
+
+type Subscription struct {
+	ChannelId string `json:"channelId"`
+	Name      string `json:"name"`
+}
+
+type Playlist struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Privacy     invidious.Privacy `json:"privacy"`
+	Videos      []string          `json:"videos"`
+}
+
+func Read(r io.Reader) (*Archive, error) {
+	var a Archive
+	if err := json.UnmarshalRead(r, &a); err != nil {
+		return nil, err
+	}
+	if a.Version < 1 || a.Version > ArchiveVersion {
+		return nil, errors.New("unsupported archive version: " + strconv.Itoa(a.Version))
+	}
+	return &a, nil
+}
+
+func Write(w io.Writer, a *Archive) error {
+	return json.MarshalWrite(w, a, jsontext.Multiline(true))
+}
+
+// Backup snapshots the subscriptions, playlists, preferences and watch
+// history of the authenticated user. It fails rather than archive a playlist
+// with missing videos, see playlists.ErrIncomplete.
+func Backup(ctx context.Context, c *invidious.Client) (*Archive, error) {
+	cc := c.WithContext(ctx)
+	a := Archive{
+		Version:  ArchiveVersion,
+		Created:  time.Now().UTC(),
+		Instance: c.InstanceURL,
+	}
+
+	subs, err := cc.Subscriptions()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range subscriptions.FromResponse(subs) {
+		a.Subscriptions = append(a.Subscriptions, Subscription(s))
+	}
+
+	pls, err := playlists.ExportAll(cc)
+	if err != nil {
+		return nil, err
+	}
+	for _, pl := range pls {
+		p := Playlist{
+			Title:       pl.Title,
+			Description: pl.Description,
+			Privacy:     pl.Privacy,
+			Videos:      make([]string, len(pl.Videos)),
+		}
+		for i, v := range pl.Videos {
+			p.Videos[i] = v.VideoId
+		}
+		a.Playlists = append(a.Playlists, p)
+	}
+
+	if a.Preferences, err = cc.Preferences(); err != nil {
+		return nil, err
+	}
+
+	for id, err := range c.HistoryAll(ctx, invidious.IterOptions{}) {
+		if err != nil {
+			return nil, err
+		}
+		a.History = append(a.History, id)
+	}
+	return &a, nil
+}
+
+type RestoreOptions struct {
+	SkipPreferences bool
+	SkipHistory     bool
+	// Resume completes the partial imports of a previous Restore, see
+	// RestoreReport.PlaylistsPartial.
+	Resume []PartialPlaylist
+}
+
+// PartialPlaylist is a playlist whose import failed after its creation.
+type PartialPlaylist struct {
+	Title      string
+	PlaylistId string
+}
+
+type RestoreReport struct {
+	SubscriptionsAdded   []string
+	SubscriptionsSkipped []string
+	PlaylistsCreated     []string // titles
+	PlaylistsSkipped     []string
+	PlaylistsPartial     []PartialPlaylist // pass them as RestoreOptions.Resume
+	HistoryAdded         int
+	HistorySkipped       int
+	PreferencesRestored  bool
+	Conflicts            []Conflict
+}
+
+// Conflict describes an item of the archive that already exists in the
+// account with different contents. Such items are left unchanged.
+type Conflict struct {
+	Kind   string // "playlist"
+	Name   string
+	Reason string
+}
+
+// Restore replays the archive into the account of the authenticated user,
+// skipping what already exists. The returned error joins the errors of the
+// failed calls; the report is always non-nil.
+func Restore(ctx context.Context, c *invidious.Client, a *Archive, opts RestoreOptions) (*RestoreReport, error) {
+	cc := c.WithContext(ctx)
+	var report RestoreReport
+	var errs []error
+
+	subs, err := cc.Subscriptions()
+	if err != nil {
+		return &report, err
+	}
+	have := make(map[string]struct{}, len(subs))
+	for _, s := range subs {
+		have[s.AuthorId] = struct{}{}
+	}
+	for _, s := range a.Subscriptions {
+		if _, ok := have[s.ChannelId]; ok {
+			report.SubscriptionsSkipped = append(report.SubscriptionsSkipped, s.ChannelId)
+			continue
+		}
+		if err = cc.AddSubscription(s.ChannelId); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		report.SubscriptionsAdded = append(report.SubscriptionsAdded, s.ChannelId)
+	}
+
+	existing, err := playlists.ExportAll(cc)
+	if err != nil {
+		return &report, errors.Join(append(errs, err)...)
+	}
+	for _, p := range a.Playlists {
+		var importOpts playlists.ImportOptions
+		if j := slices.IndexFunc(opts.Resume, func(pp PartialPlaylist) bool {
+			return pp.Title == p.Title
+		}); j >= 0 {
+			importOpts.PlaylistId = opts.Resume[j].PlaylistId
+		}
+		i := slices.IndexFunc(existing, func(pl playlists.Playlist) bool {
+			return pl.Title == p.Title
+		})
+		if i >= 0 && importOpts.PlaylistId == "" {
+			report.PlaylistsSkipped = append(report.PlaylistsSkipped, p.Title)
+			if !slices.EqualFunc(existing[i].Videos, p.Videos, func(v playlists.Video, id string) bool {
+				return v.VideoId == id
+			}) {
+				report.Conflicts = append(report.Conflicts, Conflict{
+					Kind:   "playlist",
+					Name:   p.Title,
+					Reason: "a playlist with the same title but different videos exists",
+				})
+			}
+			continue
+		}
+		pl := playlists.Playlist{
+			Title:       p.Title,
+			Description: p.Description,
+			Privacy:     p.Privacy,
+			Videos:      make([]playlists.Video, len(p.Videos)),
+		}
+		for j, id := range p.Videos {
+			pl.Videos[j] = playlists.Video{VideoId: id}
+		}
+		result, err := playlists.Import(ctx, c, &pl, importOpts)
+		if err != nil {
+			id := importOpts.PlaylistId
+			if result != nil {
+				id = result.PlaylistId
+			}
+			if id != "" {
+				report.PlaylistsPartial = append(report.PlaylistsPartial, PartialPlaylist{
+					Title:      p.Title,
+					PlaylistId: id,
+				})
+			}
+			errs = append(errs, err)
+			continue
+		}
+		report.PlaylistsCreated = append(report.PlaylistsCreated, p.Title)
+	}
+
+	if !opts.SkipPreferences && a.Preferences != nil {
+		if err = cc.UpdatePreferences(a.Preferences); err != nil {
+			errs = append(errs, err)
+		} else {
+			report.PreferencesRestored = true
+		}
+	}
+
+	if !opts.SkipHistory && len(a.History) > 0 {
+		watched := make(map[string]struct{})
+		for id, err := range c.HistoryAll(ctx, invidious.IterOptions{}) {
+			if err != nil {
+				return &report, errors.Join(append(errs, err)...)
+			}
+			watched[id] = struct{}{}
+		}
+		// Oldest first, so that the order of the history is preserved.
+		for _, id := range slices.Backward(a.History) {
+			if _, ok := watched[id]; ok {
+				report.HistorySkipped++
+				continue
+			}
+			if err = cc.AddToHistory(id); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			report.HistoryAdded++
+		}
+	}
+	return &report, errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+type BatchOptions struct {
+	Concurrency int    // default: 8
+	Region      string // see VideoRequest
+}
+
+type VideoResult struct {
+	Id    string
+	Video *VideoResponse // nil if Err != nil
+	Err   error
+}
+
+// VideosBatch fetches the videos with a pool of workers and yields them in
+// order of completion. Duplicate IDs are fetched once. The error of a video,
+// e.g. an unavailable one, is returned in its result without stopping the
+// others. Requests go through the cache and rate limiter of c.
+func (c *Client) VideosBatch(ctx context.Context, ids []string, opts BatchOptions) iter.Seq[VideoResult] {
+	return func(yield func(VideoResult) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		c := c.WithContext(ctx)
+		c.meta = nil // the workers run concurrently
+
+		seen := make(map[string]struct{}, len(ids))
+		unique := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				unique = append(unique, id)
+			}
+		}
+		concurrency := opts.Concurrency
+		if concurrency <= 0 {
+			concurrency = 8
+		}
+
+		jobs := make(chan string)
+		results := make(chan VideoResult)
+		go func() {
+			defer close(jobs)
+			for _, id := range unique {
+				select {
+				case jobs <- id:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		var wg sync.WaitGroup
+		for range min(concurrency, len(unique)) {
+			wg.Go(func() {
+				for id := range jobs {
+					v, err := c.Video(VideoRequest{Id: id, Region: opts.Region})
+					select {
+					case results <- VideoResult{Id: id, Video: v, Err: err}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			})
+		}
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for r := range results {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-json-experiment/json"
+)
+
+// ResponseCache caches the responses of GET requests. It may be shared by
+// several clients.
+type ResponseCache struct {
+	Store CacheStore
+	// TTL returns how long the response of a path stays fresh. A zero TTL
+	// disables caching for the path. Default: DefaultCacheTTL.
+	TTL func(path string) time.Duration
+	// StaleWhileRevalidate is how long an expired response is still served
+	// while it is refreshed in the background.
+	StaleWhileRevalidate time.Duration
+	// Authenticated enables caching of authenticated responses, keyed by a
+	// hash of the credentials. They are bypassed otherwise.
+	Authenticated bool
+
+	hits         atomic.Int64
+	staleHits    atomic.Int64
+	misses       atomic.Int64
+	revalidating sync.Map // key -> struct{}
+}
+
+func NewResponseCache(store CacheStore) *ResponseCache {
+	return &ResponseCache{Store: store}
+}
+
+type CacheMetrics struct {
+	Hits      int64
+	StaleHits int64 // served while revalidating
+	Misses    int64
+}
+
+func (rc *ResponseCache) Metrics() CacheMetrics {
+	return CacheMetrics{
+		Hits:      rc.hits.Load(),
+		StaleHits: rc.staleHits.Load(),
+		Misses:    rc.misses.Load(),
+	}
+}
+
+// DefaultCacheTTL returns the default TTLs of the API endpoints.
+func DefaultCacheTTL(path string) time.Duration {
+	switch {
+	case path == "/api/v1/stats":
+		return 5 * time.Minute
+	case strings.HasPrefix(path, "/api/v1/channels/"):
+		return 30 * time.Minute
+	case strings.HasPrefix(path, "/api/v1/videos/"):
+		return 10 * time.Minute
+	case strings.HasPrefix(path, "/api/v1/auth/"):
+		return time.Minute
+	default:
+		return 0
+	}
+}
+
+type CacheEntry struct {
+	Body    []byte      `json:"body"`
+	Header  http.Header `json:"header"`
+	Expires time.Time   `json:"expires"`
+}
+
+func (e *CacheEntry) record(meta *ResponseMeta) {
+	if meta != nil {
+		*meta = ResponseMeta{StatusCode: http.StatusOK, Header: e.Header.Clone(), Cached: true}
+	}
+}
+
+type CacheStore interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, e CacheEntry)
+	// DeletePrefix deletes the entries whose key starts with prefix.
+	DeletePrefix(prefix string)
+}
+
+func (rc *ResponseCache) key(c *Client, config *requestConfig) (string, bool) {
+	if !coalescable(config) {
+		return "", false
+	}
+	if config.Auth && !rc.Authenticated || rc.ttl(config.Path) <= 0 {
+		return "", false
+	}
+	return c.requestKey(config), true
+}
+
+func (rc *ResponseCache) ttl(path string) time.Duration {
+	if rc.TTL != nil {
+		return rc.TTL(path)
+	}
+	return DefaultCacheTTL(path)
+}
+
+func (rc *ResponseCache) call(c *Client, config *requestConfig, key string) error {
+	if e, ok := rc.Store.Get(key); ok {
+		now := time.Now()
+		switch {
+		case now.Before(e.Expires):
+			rc.hits.Add(1)
+			e.record(c.meta)
+			return json.Unmarshal(e.Body, config.Output, opts)
+		case now.Before(e.Expires.Add(rc.StaleWhileRevalidate)):
+			rc.staleHits.Add(1)
+			e.record(c.meta)
+			if _, loaded := rc.revalidating.LoadOrStore(key, struct{}{}); !loaded {
+				c := c.WithContext(context.WithoutCancel(c.context()))
+				c.meta = nil
+				go func() {
+					defer rc.revalidating.Delete(key)
+					rc.fetch(c, config, key) //nolint:errcheck
+				}()
+			}
+			return json.Unmarshal(e.Body, config.Output, opts)
+		}
+	}
+	rc.misses.Add(1)
+	body, err := rc.fetch(c, config, key)
+	if err != nil {
+		return err
+	}
+	return c.unmarshal(config, body)
+}
+
+func (rc *ResponseCache) fetch(c *Client, config *requestConfig, key string) ([]byte, error) {
+	body, meta, err := c.fetch(config)
+	if c.meta != nil && meta.StatusCode != 0 {
+		*c.meta = meta
+	}
+	if err != nil {
+		return nil, err
+	}
+	rc.Store.Set(key, CacheEntry{
+		Body:    body,
+		Header:  meta.Header,
+		Expires: time.Now().Add(rc.ttl(config.Path)),
+	})
+	return body, nil
+}
+
+// invalidate deletes the cached responses of the collection modified by a
+// successful request, e.g. every playlist after AddVideo.
+func (rc *ResponseCache) invalidate(c *Client, config *requestConfig) {
+	if config.Method == "GET" {
+		return
+	}
+	path := config.Path
+	if rest, ok := strings.CutPrefix(path, "/api/v1/auth/"); ok {
+		collection, _, _ := strings.Cut(rest, "/")
+		path = "/api/v1/auth/" + collection
+	}
+	rc.Store.DeletePrefix("GET " + c.InstanceURL + path)
+}
+
+// authIdentity returns a hash of the credentials of c.
+func (c *Client) authIdentity() string {
+	credentials := c.RawToken
+	if credentials == "" {
+		credentials = "SID=" + c.SessionID()
+	}
+	sum := sha256.Sum256([]byte(credentials))
+	return hex.EncodeToString(sum[:8])
+}
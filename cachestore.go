@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/go-json-experiment/json"
+)
+
+// LRUStore is an in-memory CacheStore that evicts the least recently used
+// entries.
+type LRUStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+func NewLRUStore(maxEntries int) *LRUStore {
+	return &LRUStore{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (s *LRUStore) Get(key string) (CacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.items[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	s.ll.MoveToFront(el)
+	return el.Value.(*lruItem).entry, true
+}
+
+func (s *LRUStore) Set(key string, e CacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.items[key]; ok {
+		el.Value.(*lruItem).entry = e
+		s.ll.MoveToFront(el)
+		return
+	}
+	s.items[key] = s.ll.PushFront(&lruItem{key: key, entry: e})
+	for s.maxEntries > 0 && s.ll.Len() > s.maxEntries {
+		el := s.ll.Back()
+		s.ll.Remove(el)
+		delete(s.items, el.Value.(*lruItem).key)
+	}
+}
+
+func (s *LRUStore) DeletePrefix(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, el := range s.items {
+		if strings.HasPrefix(key, prefix) {
+			s.ll.Remove(el)
+			delete(s.items, key)
+		}
+	}
+}
+
+func (s *LRUStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ll.Len()
+}
+
+// DiskStore is a CacheStore that keeps every entry in a file of the
+// directory. Errors are treated as cache misses.
+type DiskStore struct {
+	Dir string
+}
+
+type diskEntry struct {
+	Key string `json:"key"`
+	CacheEntry
+}
+
+func (s DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (s DiskStore) Get(key string) (CacheEntry, bool) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	var e diskEntry
+	if err = json.Unmarshal(data, &e); err != nil || e.Key != key {
+		return CacheEntry{}, false
+	}
+	return e.CacheEntry, true
+}
+
+func (s DiskStore) Set(key string, e CacheEntry) {
+	data, err := json.Marshal(&diskEntry{Key: key, CacheEntry: e})
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(s.Dir, 0o700); err != nil {
+		return
+	}
+	f, err := os.CreateTemp(s.Dir, "*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path(key))
+	}
+	if err != nil {
+		os.Remove(f.Name()) //nolint:errcheck
+	}
+}
+
+func (s DiskStore) DeletePrefix(prefix string) {
+	names, err := filepath.Glob(filepath.Join(s.Dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, name := range names {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		var e struct {
+			Key string `json:"key"`
+		}
+		if json.Unmarshal(data, &e) == nil && strings.HasPrefix(e.Key, prefix) {
+			os.Remove(name) //nolint:errcheck
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrUnsupportedByInstance = errors.New("unsupported by instance")
+
+type Feature string
+
+const (
+	FeatureAPI             Feature = "api"
+	FeatureClips           Feature = "clips"            // /api/v1/clips/:id
+	FeaturePosts           Feature = "posts"            // /api/v1/post/:id
+	FeatureChannelPodcasts Feature = "channel-podcasts" // /api/v1/channels/:id/podcasts
+)
+
+type Capabilities struct {
+	Version           string    // e.g. "2.20250517.0-4ea4ea6"
+	Branch            string    // e.g. "master"
+	Released          time.Time // date of Version, zero if it has another format
+	OpenRegistrations bool
+	// Features maps the probed features to their availability. Features
+	// whose probe was inconclusive are absent.
+	Features map[Feature]bool
+}
+
+// Supports reports whether the instance supports f. Features that weren't
+// probed are assumed to be supported.
+func (caps *Capabilities) Supports(f Feature) bool {
+	supported, ok := caps.Features[f]
+	return supported || !ok
+}
+
+// Capabilities determines the features supported by the instance from its
+// stats and cheap probes of the newer endpoints. Afterwards, the methods of c
+// and of its copies return an error wrapping ErrUnsupportedByInstance instead
+// of sending requests that can't succeed. Clients that weren't created by
+// NewClient don't keep the result.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	if c.caps != nil {
+		c.caps.Store(nil)
+	}
+	c = c.WithContext(ctx)
+	c.meta = nil // the probes run concurrently
+
+	caps := &Capabilities{Features: make(map[Feature]bool)}
+	stats, err := c.Stats()
+	if err != nil {
+		var e Error
+		if !errors.As(err, &e) || e.StatusCode != http.StatusForbidden && e.StatusCode != http.StatusNotFound {
+			return nil, err
+		}
+		// The API is disabled, or the URL isn't an Invidious instance.
+		caps.Features[FeatureAPI] = false
+		if c.caps != nil {
+			c.caps.Store(caps)
+		}
+		return caps, nil
+	}
+	caps.Features[FeatureAPI] = true
+	caps.Version = stats.Software.Version
+	caps.Branch = stats.Software.Branch
+	caps.OpenRegistrations = stats.OpenRegistrations
+	if _, date, ok := strings.Cut(caps.Version, "."); ok {
+		date, _, _ = strings.Cut(date, ".")
+		caps.Released, _ = time.Parse("20060102", date)
+	}
+
+	probes := map[Feature]string{
+		FeatureClips:           "/api/v1/clips/invidious-go-probe",
+		FeaturePosts:           "/api/v1/post/invidious-go-probe",
+		FeatureChannelPodcasts: "/api/v1/channels/UCinvidious-go-probe/podcasts",
+	}
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for feature, path := range probes {
+		wg.Go(func() {
+			if supported, ok := c.probe(path); ok {
+				mu.Lock()
+				caps.Features[feature] = supported
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+	if c.caps != nil {
+		c.caps.Store(caps)
+	}
+	return caps, nil
+}
+
+// probe reports whether the instance has a route for path. Routes that exist
+// answer requests with invalid IDs with a JSON error, the others with an
+// HTML 404 page. Other statuses, e.g. 429 or 502 from a proxy, are
+// inconclusive.
+func (c *Client) probe(path string) (supported, ok bool) {
+	resp, err := c.send(&requestConfig{Operation: "Capabilities", Method: "GET", Path: path})
+	if err != nil {
+		return false, false
+	}
+	resp.Body.Close() //nolint:errcheck
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299,
+		resp.StatusCode == http.StatusBadRequest,
+		resp.StatusCode == http.StatusInternalServerError:
+		return true, true
+	case resp.StatusCode != http.StatusNotFound:
+		return false, false
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return mediaType == "application/json", true
+}
+
+// featureOf returns the feature needed by a request to path.
+func featureOf(path string) (Feature, bool) {
+	rest, ok := strings.CutPrefix(path, "/api/v1/")
+	switch {
+	case !ok:
+		return "", false
+	case strings.HasPrefix(rest, "clips/"):
+		return FeatureClips, true
+	case strings.HasPrefix(rest, "post/"):
+		return FeaturePosts, true
+	case strings.HasPrefix(rest, "channels/") && strings.HasSuffix(rest, "/podcasts"):
+		return FeatureChannelPodcasts, true
+	default:
+		return FeatureAPI, true
+	}
+}
+
+// checkCapabilities returns an error if the last Capabilities call found that
+// the instance doesn't support the request to path.
+func (c *Client) checkCapabilities(path string) error {
+	if c.caps == nil {
+		return nil
+	}
+	caps := c.caps.Load()
+	if caps == nil {
+		return nil
+	}
+	if !caps.Supports(FeatureAPI) && strings.HasPrefix(path, "/api/") {
+		return fmt.Errorf("%w: %s", ErrUnsupportedByInstance, FeatureAPI)
+	}
+	if feature, ok := featureOf(path); ok && !caps.Supports(feature) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedByInstance, feature)
+	}
+	return nil
+}
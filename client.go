@@ -5,12 +5,14 @@
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-json-experiment/json"
@@ -22,19 +24,60 @@ type Client struct {
 	RawToken    string
 	UserAgent   string
 	HTTPClient  *http.Client
+	Cache       *ResponseCache // nil disables caching
+	Limiter     *RateLimiter   // nil disables rate limiting
+	Coalescer   *Coalescer     // nil disables request coalescing
+	// Interceptors wrap the sending of every request, see Interceptor.
+	Interceptors []Interceptor
+	// StrictDecoding reports the SchemaDrift of every decoded response,
+	// except cached ones, to OnSchemaDrift.
+	StrictDecoding bool
+	OnSchemaDrift  func(SchemaDrift)
+
+	ctx        context.Context
+	fields     string // see WithFields
+	projection any    // see Partial
+	meta       *ResponseMeta
+	caps       *atomic.Pointer[Capabilities] // see Capabilities
 }
 
 func NewClient(instanceURL string) *Client {
-	return &Client{InstanceURL: instanceURL}
+	return &Client{
+		InstanceURL: instanceURL,
+		Coalescer:   NewCoalescer(),
+		caps:        new(atomic.Pointer[Capabilities]),
+	}
+}
+
+// WithContext returns a shallow copy of c whose requests use ctx.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+func (c *Client) context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
 }
 
 type requestConfig struct {
-	Method string
-	Path   string
-	Auth   bool
-	Query  url.Values
-	Input  any
-	Output any
+	Operation string // name of the method, see Interceptor
+	Method    string
+	Path      string
+	Auth      bool
+	Query     url.Values
+	Header    http.Header
+	Input     any
+	Output    any
+}
+
+// responseDecoder is implemented by outputs that aren't JSON. It also
+// receives 304 Not Modified responses.
+type responseDecoder interface {
+	decodeResponse(resp *http.Response) error
 }
 
 var opts = json.JoinOptions(
@@ -63,6 +106,47 @@ func(dec *jsontext.Decoder, t *time.Time) error {
 )
 
 func (c *Client) call(config *requestConfig) error {
+	if err := c.checkCapabilities(config.Path); err != nil {
+		return err
+	}
+	if c.fields != "" && config.Method == "GET" {
+		if config.Query == nil {
+			config.Query = make(url.Values, 1)
+		}
+		config.Query.Set("fields", c.fields)
+	}
+	if c.projection != nil && coalescable(config) {
+		config.Output = c.projection
+	}
+	if c.Cache != nil {
+		if key, ok := c.Cache.key(c, config); ok {
+			return c.Cache.call(c, config, key)
+		}
+	}
+	if coalescable(config) {
+		body, meta, err := c.fetch(config)
+		if c.meta != nil && meta.StatusCode != 0 {
+			*c.meta = meta
+		}
+		if err != nil {
+			return err
+		}
+		return c.unmarshal(config, body)
+	}
+
+	resp, err := c.send(config)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if err = c.decodeResponse(resp, config); err == nil && c.Cache != nil {
+		c.Cache.invalidate(c, config)
+	}
+	return err
+}
+
+func (c *Client) send(config *requestConfig) (*http.Response, error) {
 	var query string
 	if len(config.Query) > 0 {
 		query = "?" + config.Query.Encode()
@@ -72,48 +156,87 @@ func (c *Client) call(config *requestConfig) error {
 	if config.Input != nil {
 		bodyData, err := json.Marshal(config.Input, opts)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		body = bytes.NewReader(bodyData)
 	}
 
-	url := c.InstanceURL + config.Path + query
-	req, err := http.NewRequest(config.Method, url, body)
+	req, err := c.newRequest(config.Method, config.Path+query, body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if config.Auth {
+	// Without a token, authentication relies on the SID cookie set by Login.
+	if config.Auth && c.RawToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.RawToken)
 	}
 	if config.Input != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	if c.UserAgent != "" {
-		req.Header.Set("User-Agent", c.UserAgent)
-	} else {
-		req.Header.Set("User-Agent", pkgPath+" "+Version())
+	for key, values := range config.Header {
+		req.Header[key] = values
 	}
 
-	var resp *http.Response
-	if c.HTTPClient != nil {
-		resp, err = c.HTTPClient.Do(req)
-	} else {
-		resp, err = http.DefaultClient.Do(req)
+	if c.Limiter != nil {
+		if err = c.Limiter.wait(req.Context(), c.InstanceURL, config.Path); err != nil {
+			return nil, err
+		}
 	}
+	start := time.Now()
+	resp, err := c.roundTrip(config.Operation, req, c.httpClient())
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close() //nolint:errcheck
+	if c.Limiter != nil {
+		c.Limiter.observe(c.InstanceURL, config.Path, resp)
+	}
+	if c.meta != nil {
+		c.meta.record(resp, start)
+	}
+	return resp, nil
+}
 
+func (c *Client) decodeResponse(resp *http.Response, config *requestConfig) error {
+	decoder, isDecoder := config.Output.(responseDecoder)
+	if isDecoder && resp.StatusCode == http.StatusNotModified {
+		return decoder.decodeResponse(resp)
+	}
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		return newError(resp)
 	}
 
-	if config.Output != nil {
-		err = json.UnmarshalRead(resp.Body, config.Output, opts)
+	if isDecoder {
+		return decoder.decodeResponse(resp)
+	} else if config.Output == nil {
+		return nil
+	} else if c.StrictDecoding {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		return c.unmarshal(config, body)
 	}
-	return err
+	return json.UnmarshalRead(resp.Body, config.Output, opts)
+}
+
+func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(c.context(), method, c.InstanceURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	} else {
+		req.Header.Set("User-Agent", pkgPath+" "+Version())
+	}
+	return req, nil
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
 }
 
 func newError(resp *http.Response) (e Error) {
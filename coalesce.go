@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// Coalescer coalesces concurrent identical GET requests: the first caller
+// sends the request and the others wait for its response. It may only be
+// shared by clients whose requests are sent identically, i.e. with the same
+// UserAgent, HTTPClient and Interceptors.
+type Coalescer struct {
+	mu sync.Mutex
+	m  map[string]*flight
+}
+
+func NewCoalescer() *Coalescer {
+	return &Coalescer{m: make(map[string]*flight)}
+}
+
+type flight struct {
+	done    chan struct{}
+	body    []byte
+	meta    ResponseMeta
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do returns the result of fn, sharing it with the concurrent callers using
+// the same key. fn runs with a context that is only canceled once every
+// caller has given up waiting.
+func (g *Coalescer) do(ctx context.Context, key string, fn func(ctx context.Context, meta *ResponseMeta) ([]byte, error)) ([]byte, ResponseMeta, error) {
+	g.mu.Lock()
+	f, ok := g.m[key]
+	if !ok {
+		fctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.m[key] = f
+		go func() {
+			f.body, f.err = fn(fctx, &f.meta)
+			g.mu.Lock()
+			g.forget(key, f)
+			g.mu.Unlock()
+			cancel()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		meta := f.meta
+		meta.Header = meta.Header.Clone()
+		return f.body, meta, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		// A new caller joining after the last one left must not get a
+		// canceled flight, so it's forgotten in the same critical section.
+		if f.waiters--; f.waiters == 0 {
+			g.forget(key, f)
+			f.cancel()
+		}
+		g.mu.Unlock()
+		return nil, ResponseMeta{}, ctx.Err()
+	}
+}
+
+// forget deletes f from the group. g.mu must be held.
+func (g *Coalescer) forget(key string, f *flight) {
+	if g.m[key] == f {
+		delete(g.m, key)
+	}
+}
+
+// coalescable reports whether the response of config can be shared, i.e. it's
+// a GET request with a JSON output. Requests with extra headers aren't, since
+// the headers may change the response.
+func coalescable(config *requestConfig) bool {
+	if config.Method != "GET" || config.Output == nil || len(config.Header) > 0 {
+		return false
+	}
+	_, isDecoder := config.Output.(responseDecoder)
+	return !isDecoder
+}
+
+// requestKey identifies the response of config.
+func (c *Client) requestKey(config *requestConfig) string {
+	key := config.Method + " " + c.InstanceURL + config.Path
+	if len(config.Query) > 0 {
+		key += "?" + config.Query.Encode()
+	}
+	if config.Auth {
+		key += "#" + c.authIdentity()
+	}
+	return key
+}
+
+// fetch returns the body of the successful response of config. Callers
+// must not modify it.
+func (c *Client) fetch(config *requestConfig) ([]byte, ResponseMeta, error) {
+	fn := func(ctx context.Context, meta *ResponseMeta) ([]byte, error) {
+		c := c.WithContext(ctx)
+		c.meta = meta
+		resp, err := c.send(config)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return nil, newError(resp)
+		}
+		return io.ReadAll(resp.Body)
+	}
+	if c.Coalescer == nil {
+		var meta ResponseMeta
+		body, err := fn(c.context(), &meta)
+		return body, meta, err
+	}
+	return c.Coalescer.do(c.context(), c.requestKey(config), fn)
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package feedgen
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Id       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   *atomAuthor `xml:"author,omitempty"`
+	Logo     string      `xml:"logo,omitempty"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel    string `xml:"rel,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Id        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Updated   string      `xml:"updated"`
+	Published string      `xml:"published,omitempty"`
+	Links     []atomLink  `xml:"link"`
+	Author    *atomAuthor `xml:"author,omitempty"`
+	Summary   string      `xml:"summary,omitempty"`
+}
+
+func WriteAtom(w io.Writer, f *Feed) error {
+	doc := atomFeed{
+		Id:       f.Link,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  atomTime(f.Updated),
+		Links:    []atomLink{{Rel: "alternate", Href: f.Link}},
+		Logo:     f.Image,
+		Entries:  make([]atomEntry, len(f.Items)),
+	}
+	if f.Author != "" {
+		doc.Author = &atomAuthor{Name: f.Author}
+	}
+	for i, item := range f.Items {
+		e := atomEntry{
+			Id:        "yt:video:" + item.VideoId,
+			Title:     item.Title,
+			Updated:   atomTime(item.Published),
+			Published: atomTime(item.Published),
+			Links:     []atomLink{{Rel: "alternate", Href: item.Link}},
+			Summary:   item.Description,
+		}
+		if item.Author != "" {
+			e.Author = &atomAuthor{Name: item.Author}
+		}
+		if enc := item.Enclosure; enc != nil {
+			e.Links = append(e.Links, atomLink{
+				Rel:    "enclosure",
+				Href:   enc.Url,
+				Type:   enc.Type,
+				Length: enc.Length,
+			})
+		}
+		doc.Entries[i] = e
+	}
+	return writeXML(w, &doc)
+}
+
+func atomTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Unix(0, 0)
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func writeXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+// Package feedgen generates Atom, RSS 2.0 and podcast feeds from channels,
+// playlists and the subscription feed.
+package feedgen
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+type Feed struct {
+	Title       string
+	Link        string
+	Description string
+	Author      string
+	Image       string
+	Updated     time.Time
+	Items       []Item
+}
+
+type Item struct {
+	VideoId       string
+	Title         string
+	Link          string
+	Description   string
+	Author        string
+	Published     time.Time
+	Thumbnail     string
+	LengthSeconds int64
+	Enclosure     *Enclosure // set by AddEnclosures
+}
+
+type Enclosure struct {
+	Url    string
+	Type   string // MIME type
+	Length int64  // bytes
+}
+
+// FromChannel builds a feed from the latest videos of a channel.
+func FromChannel(c *invidious.Client, ucid string) (*Feed, error) {
+	ch, err := c.Channel(ucid)
+	if err != nil {
+		return nil, err
+	}
+	f := Feed{
+		Title:       ch.Author,
+		Link:        c.InstanceURL + "/channel/" + ch.AuthorId,
+		Description: ch.Description,
+		Author:      ch.Author,
+	}
+	if len(ch.AuthorThumbnails) > 0 {
+		f.Image = largestImage(ch.AuthorThumbnails)
+	}
+	for _, v := range ch.LatestVideos {
+		f.Items = append(f.Items, itemOf(c, &v))
+	}
+	f.Updated = latest(f.Items)
+	return &f, nil
+}
+
+// FromPlaylist builds a feed from a public playlist.
+func FromPlaylist(c *invidious.Client, plid string) (*Feed, error) {
+	resp, err := c.PlaylistFeed(invidious.AtomFeedRequest{Id: plid})
+	if err != nil {
+		return nil, err
+	}
+	f := Feed{
+		Title:  resp.Title,
+		Link:   c.InstanceURL + "/playlist?list=" + url.QueryEscape(plid),
+		Author: resp.Author,
+	}
+	for _, v := range resp.Videos {
+		f.Items = append(f.Items, itemOf(c, &v))
+	}
+	f.Updated = latest(f.Items)
+	return &f, nil
+}
+
+// FromFeed builds a feed from the first page of the subscription feed of the
+// authenticated user.
+func FromFeed(c *invidious.Client) (*Feed, error) {
+	resp, err := c.Feed(invidious.FeedRequest{})
+	if err != nil {
+		return nil, err
+	}
+	f := Feed{
+		Title: "Subscriptions",
+		Link:  c.InstanceURL + "/feed/subscriptions",
+	}
+	for _, v := range resp.Videos {
+		item := Item{
+			VideoId:       v.VideoId,
+			Title:         v.Title,
+			Link:          watchURL(c, v.VideoId),
+			Author:        v.Author,
+			Published:     time.Unix(v.Published, 0).UTC(),
+			LengthSeconds: v.LengthSeconds,
+		}
+		for _, t := range v.VideoThumbnails {
+			if t.Quality == "high" || item.Thumbnail == "" {
+				item.Thumbnail = t.Url
+			}
+		}
+		f.Items = append(f.Items, item)
+	}
+	f.Updated = latest(f.Items)
+	return &f, nil
+}
+
+func itemOf(c *invidious.Client, v *invidious.VideoObject) Item {
+	item := Item{
+		VideoId:       v.VideoId,
+		Title:         v.Title,
+		Link:          watchURL(c, v.VideoId),
+		Description:   v.Description,
+		Author:        v.Author,
+		Published:     v.Published.UTC(),
+		LengthSeconds: v.LengthSeconds,
+	}
+	for _, t := range v.VideoThumbnails {
+		if t.Quality == "high" || item.Thumbnail == "" {
+			item.Thumbnail = t.Url
+		}
+	}
+	return item
+}
+
+func watchURL(c *invidious.Client, id string) string {
+	return c.InstanceURL + "/watch?v=" + url.QueryEscape(id)
+}
+
+func largestImage(images []invidious.ImageObject) string {
+	best := images[0]
+	for _, img := range images[1:] {
+		if img.Width > best.Width {
+			best = img
+		}
+	}
+	return best.Url
+}
+
+func latest(items []Item) time.Time {
+	var t time.Time
+	for _, item := range items {
+		if item.Published.After(t) {
+			t = item.Published
+		}
+	}
+	return t
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package feedgen
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+var ErrNoAudio = errors.New("no audio-only format")
+
+type EnclosureOptions struct {
+	// DirectURLs uses the expiring googlevideo URLs instead of the stable
+	// /latest_version URLs of the instance.
+	DirectURLs  bool
+	Concurrency int // default: 4
+}
+
+// AddEnclosures sets the enclosure of every item to the audio-only format with
+// the highest bitrate. Items whose video can't be fetched keep a nil
+// enclosure; their errors are joined in the returned error.
+func AddEnclosures(ctx context.Context, c *invidious.Client, f *Feed, opts EnclosureOptions) error {
+	// The videos are fetched concurrently.
+	c = c.WithContext(ctx).WithResponseMeta(nil)
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+		sem  = make(chan struct{}, concurrency)
+	)
+	for i := range f.Items {
+		item := &f.Items[i]
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			enc, err := enclosureOf(c, item.VideoId, opts.DirectURLs)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, errors.New(item.VideoId+": "+err.Error()))
+				mu.Unlock()
+				return
+			}
+			item.Enclosure = enc
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func enclosureOf(c *invidious.Client, videoId string, directURL bool) (*Enclosure, error) {
+	v, err := c.Video(invidious.VideoRequest{Id: videoId})
+	if err != nil {
+		return nil, err
+	}
+	best := -1
+	var bestBitrate int64
+	for i, f := range v.AdaptiveFormats {
+		if !strings.HasPrefix(f.Type, "audio/") {
+			continue
+		}
+		bitrate, _ := strconv.ParseInt(f.Bitrate, 10, 64)
+		if best < 0 || bitrate > bestBitrate {
+			best, bestBitrate = i, bitrate
+		}
+	}
+	if best < 0 {
+		return nil, ErrNoAudio
+	}
+	f := &v.AdaptiveFormats[best]
+
+	mimeType, _, _ := strings.Cut(f.Type, ";")
+	length, _ := strconv.ParseInt(f.Clen, 10, 64)
+	enc := Enclosure{Url: f.Url, Type: strings.TrimSpace(mimeType), Length: length}
+	if !directURL {
+		query := make(url.Values, 3)
+		query.Set("id", videoId)
+		query.Set("itag", f.Itag)
+		query.Set("local", "true")
+		enc.Url = c.InstanceURL + "/latest_version?" + query.Encode()
+	}
+	return &enc, nil
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package feedgen
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+type rssDocument struct {
+	XMLName  xml.Name   `xml:"rss"`
+	Version  string     `xml:"version,attr"`
+	ITunesNS string     `xml:"xmlns:itunes,attr,omitempty"`
+	Channel  rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title          string          `xml:"title"`
+	Link           string          `xml:"link"`
+	Description    string          `xml:"description"`
+	LastBuildDate  string          `xml:"lastBuildDate,omitempty"`
+	Image          *rssImage       `xml:"image,omitempty"`
+	ITunesAuthor   string          `xml:"itunes:author,omitempty"`
+	ITunesSummary  string          `xml:"itunes:summary,omitempty"`
+	ITunesImage    *itunesImage    `xml:"itunes:image,omitempty"`
+	ITunesExplicit string          `xml:"itunes:explicit,omitempty"`
+	ITunesCategory *itunesCategory `xml:"itunes:category,omitempty"`
+	Items          []rssItem       `xml:"item"`
+}
+
+type rssImage struct {
+	Url   string `xml:"url"`
+	Title string `xml:"title"`
+	Link  string `xml:"link"`
+}
+
+type itunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type itunesCategory struct {
+	Text string `xml:"text,attr"`
+}
+
+type rssItem struct {
+	Title          string        `xml:"title"`
+	Link           string        `xml:"link"`
+	Description    string        `xml:"description,omitempty"`
+	Guid           rssGuid       `xml:"guid"`
+	PubDate        string        `xml:"pubDate,omitempty"`
+	Enclosure      *rssEnclosure `xml:"enclosure,omitempty"`
+	ITunesAuthor   string        `xml:"itunes:author,omitempty"`
+	ITunesDuration string        `xml:"itunes:duration,omitempty"`
+	ITunesImage    *itunesImage  `xml:"itunes:image,omitempty"`
+	ITunesSummary  string        `xml:"itunes:summary,omitempty"`
+}
+
+type rssGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	Url    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int64  `xml:"length,attr"`
+}
+
+func WriteRSS(w io.Writer, f *Feed) error {
+	return writeRSS(w, f, false)
+}
+
+// WritePodcast writes an RSS 2.0 feed with iTunes tags. Items without an
+// enclosure are omitted, see AddEnclosures.
+func WritePodcast(w io.Writer, f *Feed) error {
+	return writeRSS(w, f, true)
+}
+
+func writeRSS(w io.Writer, f *Feed, podcast bool) error {
+	doc := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Description,
+		},
+	}
+	ch := &doc.Channel
+	if ch.Description == "" {
+		ch.Description = f.Title
+	}
+	if !f.Updated.IsZero() {
+		ch.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+	if f.Image != "" {
+		ch.Image = &rssImage{Url: f.Image, Title: f.Title, Link: f.Link}
+	}
+	if podcast {
+		doc.ITunesNS = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+		ch.ITunesAuthor = f.Author
+		ch.ITunesSummary = f.Description
+		ch.ITunesExplicit = "false"
+		ch.ITunesCategory = &itunesCategory{Text: "TV & Film"}
+		if f.Image != "" {
+			ch.ITunesImage = &itunesImage{Href: f.Image}
+		}
+	}
+
+	for _, item := range f.Items {
+		if podcast && item.Enclosure == nil {
+			continue
+		}
+		it := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Description,
+			Guid:        rssGuid{Value: "yt:video:" + item.VideoId},
+		}
+		if !item.Published.IsZero() {
+			it.PubDate = item.Published.UTC().Format(time.RFC1123Z)
+		}
+		if enc := item.Enclosure; enc != nil {
+			it.Enclosure = &rssEnclosure{Url: enc.Url, Type: enc.Type, Length: enc.Length}
+		}
+		if podcast {
+			it.ITunesAuthor = item.Author
+			it.ITunesSummary = item.Description
+			if item.LengthSeconds > 0 {
+				it.ITunesDuration = strconv.FormatInt(item.LengthSeconds, 10)
+			}
+			if item.Thumbnail != "" {
+				it.ITunesImage = &itunesImage{Href: item.Thumbnail}
+			}
+		}
+		ch.Items = append(ch.Items, it)
+	}
+	return writeXML(w, &doc)
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"encoding"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-json-experiment/json"
+)
+
+// WithFields returns a shallow copy of c whose GET requests only ask for the
+// given JSON members, e.g. "videoId,title,adaptiveFormats(url,itag)". The
+// other members of the responses are left zero.
+func (c *Client) WithFields(fields string) *Client {
+	c2 := *c
+	c2.fields = fields
+	return &c2
+}
+
+// Partial calls get with a copy of c that requests the fields of T and
+// decodes the response into T instead of the output of the method:
+//
+//	type video struct {
+//		VideoId string `json:"videoId"`
+//		Title   string `json:"title"`
+//	}
+//	v, err := invidious.Partial[video](c, func(c *invidious.Client) error {
+//		_, err := c.Video(invidious.VideoRequest{Id: id})
+//		return err
+//	})
+func Partial[T any](c *Client, get func(c *Client) error) (*T, error) {
+	var v T
+	c2 := c.WithFields(FieldsOf[T]())
+	c2.projection = &v
+	if err := get(c2); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+var fieldsCache sync.Map // reflect.Type -> string
+
+// FieldsOf returns the fields parameter selecting the JSON members of T,
+// which must be a struct. Nested structs, including slice elements, are
+// selected member by member.
+func FieldsOf[T any]() string {
+	t := reflect.TypeFor[T]()
+	if fields, ok := fieldsCache.Load(t); ok {
+		return fields.(string)
+	}
+	fields := strings.Join(fieldsOf(t, nil), ",")
+	fieldsCache.Store(t, fields)
+	return fields
+}
+
+var (
+	unmarshalerType     = reflect.TypeFor[json.Unmarshaler]()
+	unmarshalerFromType = reflect.TypeFor[json.UnmarshalerFrom]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// decodesItself reports whether t implements one of the unmarshaler
+// interfaces, in which case its members aren't known.
+func decodesItself(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(unmarshalerType) || pt.Implements(unmarshalerFromType) || pt.Implements(textUnmarshalerType)
+}
+
+// memberStruct returns the struct type whose members are selected for a
+// member of type t, if any.
+func memberStruct(t reflect.Type) (reflect.Type, bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || decodesItself(t) {
+		return nil, false
+	}
+	return t, true
+}
+
+type member struct {
+	name string
+	typ  reflect.Type
+}
+
+// membersOf returns the JSON members of the struct type t.
+func membersOf(t reflect.Type) []member {
+	var members []member
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("json")
+		name, options, _ := strings.Cut(tag, ",")
+		switch {
+		case tag == "-":
+			continue
+		case f.Anonymous && name == "" || strings.Contains(options, "inline"):
+			if st, ok := memberStruct(f.Type); ok {
+				members = append(members, membersOf(st)...)
+			}
+			continue
+		case !f.IsExported():
+			continue
+		case !hasTag || name == "":
+			name = f.Name
+		}
+		members = append(members, member{name: name, typ: f.Type})
+	}
+	return members
+}
+
+func fieldsOf(t reflect.Type, visiting []reflect.Type) []string {
+	t, ok := memberStruct(t)
+	if !ok || slices.Contains(visiting, t) {
+		return nil
+	}
+	visiting = append(visiting, t)
+
+	var fields []string
+	for _, m := range membersOf(t) {
+		name := m.name
+		if sub := fieldsOf(m.typ, visiting); len(sub) > 0 {
+			name += "(" + strings.Join(sub, ",") + ")"
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
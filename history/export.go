@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package history
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/antoniszymanski/invidious-go"
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// Record is a watched video with its metadata.
+type Record struct {
+	VideoId       string    `json:"videoId"`
+	Title         string    `json:"title"`
+	Author        string    `json:"author"`
+	AuthorId      string    `json:"authorId"`
+	LengthSeconds int32     `json:"lengthSeconds"`
+	Published     time.Time `json:"published,omitzero"`
+	Error         string    `json:"error,omitempty"` // the video couldn't be fetched
+}
+
+// Cache stores the records fetched by Export.
+type Cache interface {
+	Get(videoId string) (Record, bool)
+	Put(Record)
+}
+
+type MemoryCache struct {
+	m sync.Map
+}
+
+func (c *MemoryCache) Get(videoId string) (Record, bool) {
+	v, ok := c.m.Load(videoId)
+	if !ok {
+		return Record{}, false
+	}
+	return v.(Record), true
+}
+
+func (c *MemoryCache) Put(r Record) {
+	c.m.Store(r.VideoId, r)
+}
+
+type ExportOptions struct {
+	Concurrency int   // default: 4
+	Cache       Cache // records with an error aren't cached
+}
+
+// Export fetches the metadata of the videos. Videos that can't be fetched are
+// returned with Record.Error set; the returned error is only set when ctx is
+// done.
+func Export(ctx context.Context, c *invidious.Client, ids []string, opts ExportOptions) ([]Record, error) {
+	// The videos are fetched concurrently.
+	c = c.WithContext(ctx).WithResponseMeta(nil)
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	records := make([]Record, len(ids))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, id := range ids {
+		if opts.Cache != nil {
+			if r, ok := opts.Cache.Get(id); ok {
+				records[i] = r
+				continue
+			}
+		}
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return records, ctx.Err()
+		case sem <- struct{}{}:
+		}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			records[i] = fetch(c, id)
+			if opts.Cache != nil && records[i].Error == "" {
+				opts.Cache.Put(records[i])
+			}
+		})
+	}
+	wg.Wait()
+	return records, ctx.Err()
+}
+
+var errNoVideoId = errors.New("missing video ID")
+
+func fetch(c *invidious.Client, id string) Record {
+	if id == "" {
+		return Record{Error: errNoVideoId.Error()}
+	}
+	v, err := c.Video(invidious.VideoRequest{Id: id})
+	if err != nil {
+		return Record{VideoId: id, Error: err.Error()}
+	}
+	return Record{
+		VideoId:       id,
+		Title:         v.Title,
+		Author:        v.Author,
+		AuthorId:      v.AuthorId,
+		LengthSeconds: v.LengthSeconds,
+		Published:     time.Unix(v.Published, 0).UTC(),
+	}
+}
+
+func WriteJSON(w io.Writer, records []Record) error {
+	return json.MarshalWrite(w, records, jsontext.Multiline(true))
+}
+
+func WriteCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"Video ID", "Title", "Author", "Author ID", "Length Seconds", "Published", "Error",
+	}); err != nil {
+		return err
+	}
+	for _, r := range records {
+		var published string
+		if !r.Published.IsZero() {
+			published = r.Published.Format(time.RFC3339)
+		}
+		if err := cw.Write([]string{
+			r.VideoId,
+			r.Title,
+			r.Author,
+			r.AuthorId,
+			strconv.FormatInt(int64(r.LengthSeconds), 10),
+			published,
+			r.Error,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
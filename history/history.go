@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+// Package history imports watch history from Google Takeout and exports it
+// with video metadata.
+package history
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+type ImportOptions struct {
+	// Interval is the minimum delay between AddToHistory calls (default: 1s).
+	Interval time.Duration
+	// Offset resumes a previous import, see ImportResult.Next.
+	Offset int
+	// SkipExisting doesn't add videos already in the history.
+	SkipExisting bool
+}
+
+type ImportResult struct {
+	Added   int
+	Skipped int
+	// Next is the offset from which an interrupted import can be resumed.
+	Next int
+}
+
+// Import adds the entries to the history of the authenticated user, oldest
+// first, so that the order of the history is preserved. A video watched
+// several times is added once. On error, the result tells where to resume.
+func Import(ctx context.Context, c *invidious.Client, entries []Entry, opts ImportOptions) (*ImportResult, error) {
+	ids := Order(entries)
+	result := ImportResult{Next: min(max(opts.Offset, 0), len(ids))}
+
+	existing := make(map[string]struct{})
+	if opts.SkipExisting {
+		for id, err := range c.HistoryAll(ctx, invidious.IterOptions{}) {
+			if err != nil {
+				return &result, err
+			}
+			existing[id] = struct{}{}
+		}
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c = c.WithContext(ctx)
+	for first := true; result.Next < len(ids); result.Next++ {
+		id := ids[result.Next]
+		if _, ok := existing[id]; ok {
+			result.Skipped++
+			continue
+		}
+		if !first {
+			select {
+			case <-ctx.Done():
+				return &result, ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		first = false
+		if err := c.AddToHistory(id); err != nil {
+			return &result, err
+		}
+		result.Added++
+	}
+	return &result, nil
+}
+
+// Order returns the IDs of the watched videos, oldest first, keeping only the
+// latest occurrence of every video. Entries are expected newest first.
+func Order(entries []Entry) []string {
+	seen := make(map[string]struct{}, len(entries))
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if _, ok := seen[e.VideoId]; ok {
+			continue
+		}
+		seen[e.VideoId] = struct{}{}
+		ids = append(ids, e.VideoId)
+	}
+	slices.Reverse(ids)
+	return ids
+}
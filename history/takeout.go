@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package history
+
+import (
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-json-experiment/json"
+)
+
+// Entry is a watched video from a Google Takeout watch history.
+type Entry struct {
+	VideoId string
+	Title   string
+	Channel string
+	Time    time.Time // zero if unknown
+}
+
+type takeoutJSONEntry struct {
+	Header    string `json:"header"`
+	Title     string `json:"title"`
+	TitleUrl  string `json:"titleUrl"`
+	Subtitles []struct {
+		Name string `json:"name"`
+		Url  string `json:"url"`
+	} `json:"subtitles"`
+	Time time.Time `json:"time"`
+}
+
+// ReadTakeoutJSON reads watch-history.json. Entries are returned as in the
+// file, newest first.
+func ReadTakeoutJSON(r io.Reader) ([]Entry, error) {
+	var raw []takeoutJSONEntry
+	if err := json.UnmarshalRead(r, &raw); err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(raw))
+	for _, e := range raw {
+		id := videoIdFromURL(e.TitleUrl)
+		if id == "" {
+			continue // removed videos, ads, YouTube Music searches, ...
+		}
+		entry := Entry{
+			VideoId: id,
+			Title:   strings.TrimPrefix(e.Title, "Watched "),
+			Time:    e.Time,
+		}
+		if len(e.Subtitles) > 0 {
+			entry.Channel = e.Subtitles[0].Name
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+var reTakeoutHTMLEntry = regexp.MustCompile(
+	`<a href="(https://www\.youtube\.com/watch\?v=[^"]+)">([^<]*)</a>(?:<br>\s*<a href="[^"]*">([^<]*)</a>)?`,
+)
+
+// ReadTakeoutHTML reads watch-history.html. Entries are returned as in the
+// file, newest first, without their time, which is formatted in the locale of
+// the account.
+func ReadTakeoutHTML(r io.Reader) ([]Entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, m := range reTakeoutHTMLEntry.FindAllSubmatch(data, -1) {
+		id := videoIdFromURL(html.UnescapeString(string(m[1])))
+		if id == "" {
+			continue
+		}
+		entries = append(entries, Entry{
+			VideoId: id,
+			Title:   html.UnescapeString(string(m[2])),
+			Channel: html.UnescapeString(string(m[3])),
+		})
+	}
+	return entries, nil
+}
+
+func videoIdFromURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("v")
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+// Package instances reads the public Invidious instance directory and selects
+// instances from it.
+package instances
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/antoniszymanski/invidious-go"
+	"github.com/antoniszymanski/option-go"
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// DirectoryURL is the URL of the instance directory read by Read.
+const DirectoryURL = "https://api.invidious.io/instances.json"
+
+type Instance struct {
+	Host   string
+	Uri    string // e.g. "https://yewtu.be"
+	Type   string // "https", "onion" or "i2p"
+	Region string // ISO 3166 country code
+	Flag   string
+	Api    option.Option[bool]
+	Cors   option.Option[bool]
+	Uptime option.Option[float64] // percentage, as measured by the monitor
+}
+
+// Client returns a client of the instance.
+func (i *Instance) Client() *invidious.Client {
+	return invidious.NewClient(strings.TrimSuffix(i.Uri, "/"))
+}
+
+// Nullable members are pointers, since Option doesn't consume the null token
+// when decoded with jsontext.
+type instanceInfo struct {
+	Flag    string `json:"flag"`
+	Region  string `json:"region"`
+	Cors    *bool  `json:"cors"`
+	Api     *bool  `json:"api"`
+	Type    string `json:"type"`
+	Uri     string `json:"uri"`
+	Monitor *struct {
+		Uptime *float64 `json:"uptime"`
+	} `json:"monitor"`
+}
+
+func optionOf[T any](p *T) option.Option[T] {
+	if p == nil {
+		return option.None[T]()
+	}
+	return option.Some(*p)
+}
+
+// Read reads a directory in the format of DirectoryURL: a JSON array of
+// [host, info] pairs.
+func Read(r io.Reader) ([]Instance, error) {
+	var entries [][]jsontext.Value
+	if err := json.UnmarshalRead(r, &entries); err != nil {
+		return nil, err
+	}
+	list := make([]Instance, 0, len(entries))
+	for _, entry := range entries {
+		if len(entry) != 2 {
+			return nil, errors.New("invalid directory entry")
+		}
+		var (
+			host string
+			info instanceInfo
+		)
+		if err := json.Unmarshal(entry[0], &host); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(entry[1], &info); err != nil {
+			return nil, errors.Join(errors.New(host), err)
+		}
+		i := Instance{
+			Host:   host,
+			Uri:    info.Uri,
+			Type:   info.Type,
+			Region: info.Region,
+			Flag:   info.Flag,
+			Api:    optionOf(info.Api),
+			Cors:   optionOf(info.Cors),
+		}
+		if info.Monitor != nil {
+			i.Uptime = optionOf(info.Monitor.Uptime)
+		}
+		list = append(list, i)
+	}
+	return list, nil
+}
+
+func ReadFile(name string) ([]Instance, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+	return Read(f)
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package instances
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+var ErrNoInstance = errors.New("no reachable instance")
+
+type Filter struct {
+	Api       bool     // only instances with the API enabled
+	Https     bool     // only clearnet HTTPS instances
+	Regions   []string // only instances of these regions, if any
+	MinUptime float64  // percentage; instances without uptime are excluded if > 0
+}
+
+func (f *Filter) Match(i *Instance) bool {
+	if f.Api && !i.Api.IsSomeAnd(func(api bool) bool { return api }) {
+		return false
+	}
+	if f.Https && (i.Type != "https" || !strings.HasPrefix(i.Uri, "https://")) {
+		return false
+	}
+	if len(f.Regions) > 0 && !slices.ContainsFunc(f.Regions, func(region string) bool {
+		return strings.EqualFold(region, i.Region)
+	}) {
+		return false
+	}
+	if f.MinUptime > 0 && !i.Uptime.IsSomeAnd(func(uptime float64) bool { return uptime >= f.MinUptime }) {
+		return false
+	}
+	return true
+}
+
+// Select returns the instances matching f.
+func Select(list []Instance, f Filter) []Instance {
+	var selected []Instance
+	for i := range list {
+		if f.Match(&list[i]) {
+			selected = append(selected, list[i])
+		}
+	}
+	return selected
+}
+
+type RankOptions struct {
+	Concurrency int           // default: 8
+	Timeout     time.Duration // per instance; default: 5s
+	HTTPClient  *http.Client
+	UserAgent   string
+}
+
+type Ranked struct {
+	Instance
+	Latency time.Duration // of the Stats request
+	Version string
+	Err     error // nil if the instance is reachable
+}
+
+// Rank measures the latency of the Stats endpoint of every instance. The
+// reachable instances come first, fastest first.
+func Rank(ctx context.Context, list []Instance, opts RankOptions) []Ranked {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	ranked := make([]Ranked, len(list))
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	for i := range list {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			r := &ranked[i]
+			r.Instance = list[i]
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			c := r.Client()
+			c.HTTPClient = opts.HTTPClient
+			c.UserAgent = opts.UserAgent
+			start := time.Now()
+			stats, err := c.WithContext(ctx).Stats()
+			r.Latency = time.Since(start)
+			if err != nil {
+				r.Err = err
+				return
+			}
+			r.Version = stats.Software.Version
+		})
+	}
+	wg.Wait()
+
+	slices.SortStableFunc(ranked, func(a, b Ranked) int {
+		if (a.Err == nil) != (b.Err == nil) {
+			if a.Err == nil {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.Latency, b.Latency)
+	})
+	return ranked
+}
+
+// Best returns a client of the fastest reachable instance.
+func Best(ranked []Ranked) (*invidious.Client, error) {
+	if len(ranked) == 0 || ranked[0].Err != nil {
+		return nil, ErrNoInstance
+	}
+	return ranked[0].Client(), nil
+}
+
+// Pool distributes requests over several instances.
+type Pool struct {
+	clients []*invidious.Client
+	next    atomic.Uint64
+}
+
+// NewPool returns a pool of the n fastest reachable instances, or of all of
+// them if n <= 0.
+func NewPool(ranked []Ranked, n int) (*Pool, error) {
+	p := new(Pool)
+	for i := range ranked {
+		if ranked[i].Err != nil || n > 0 && len(p.clients) == n {
+			break
+		}
+		p.clients = append(p.clients, ranked[i].Client())
+	}
+	if len(p.clients) == 0 {
+		return nil, ErrNoInstance
+	}
+	return p, nil
+}
+
+// Client returns the clients of the pool in turn.
+func (p *Pool) Client() *invidious.Client {
+	return p.clients[(p.next.Add(1)-1)%uint64(len(p.clients))]
+}
+
+// Clients returns the clients of the pool, fastest first. Their fields may
+// be set before the pool is used.
+func (p *Pool) Clients() []*invidious.Client {
+	return p.clients
+}
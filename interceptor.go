@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Handler sends a request and returns its response.
+type Handler func(req *http.Request) (*http.Response, error)
+
+// Interceptor wraps the sending of the requests of a Client. op is the name
+// of the method that sent req, e.g. "Video" or "AddVideo". An interceptor may
+// modify req before calling next, and must return the response of next
+// unless it handles the request itself.
+type Interceptor func(op string, req *http.Request, next Handler) (*http.Response, error)
+
+// roundTrip sends req through the interceptors of c, the first of which is
+// the outermost.
+func (c *Client) roundTrip(op string, req *http.Request, hc *http.Client) (*http.Response, error) {
+	next := Handler(hc.Do)
+	for i := len(c.Interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := c.Interceptors[i], next
+		next = func(req *http.Request) (*http.Response, error) {
+			return interceptor(op, req, inner)
+		}
+	}
+	return next(req)
+}
+
+// RedactHeader returns a copy of h without the credentials of the Client.
+func RedactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	if auth := h.Get("Authorization"); auth != "" {
+		scheme, _, _ := strings.Cut(auth, " ")
+		h.Set("Authorization", scheme+" REDACTED")
+	}
+	if h.Get("Cookie") != "" {
+		h.Set("Cookie", "REDACTED")
+	}
+	return h
+}
+
+// redactURL returns u without its password and token query parameter.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	if !query.Has("token") {
+		return u.Redacted()
+	}
+	query.Set("token", "REDACTED")
+	u2 := *u
+	u2.RawQuery = query.Encode()
+	return u2.Redacted()
+}
+
+// LogInterceptor logs every request at the info level, or the error level if
+// it fails. The redacted headers are logged at the debug level.
+func LogInterceptor(logger *slog.Logger) Interceptor {
+	return func(op string, req *http.Request, next Handler) (*http.Response, error) {
+		ctx := req.Context()
+		attrs := []slog.Attr{
+			slog.String("op", op),
+			slog.String("method", req.Method),
+			slog.String("url", redactURL(req.URL)),
+		}
+		if logger.Enabled(ctx, slog.LevelDebug) {
+			attrs = append(attrs, slog.Any("header", RedactHeader(req.Header)))
+		}
+		start := time.Now()
+		resp, err := next(req)
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+		if err != nil {
+			attrs = append(attrs, slog.Any("error", err))
+			logger.LogAttrs(ctx, slog.LevelError, "invidious request failed", attrs...)
+			return resp, err
+		}
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		level := slog.LevelInfo
+		if resp.StatusCode >= 400 {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "invidious request", attrs...)
+		return resp, nil
+	}
+}
+
+// Timing is the duration of the phases of a request. Phases that didn't
+// happen, e.g. on a reused connection, are zero.
+type Timing struct {
+	DNS          time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	FirstByte    time.Duration // since the request was sent
+	Total        time.Duration // until the response headers were received
+	ReusedConn   bool
+}
+
+// TraceInterceptor reports the Timing of every request to record.
+func TraceInterceptor(record func(op string, req *http.Request, t Timing)) Interceptor {
+	return func(op string, req *http.Request, next Handler) (*http.Response, error) {
+		var (
+			mu    sync.Mutex // the dials of dual-stack hosts are concurrent
+			t     Timing
+			start struct{ dns, connect, tls, wrote time.Time }
+		)
+		mark := func(t *time.Time) {
+			mu.Lock()
+			*t = time.Now()
+			mu.Unlock()
+		}
+		since := func(d *time.Duration, t *time.Time) {
+			mu.Lock()
+			*d = time.Since(*t)
+			mu.Unlock()
+		}
+		trace := &httptrace.ClientTrace{
+			DNSStart:          func(httptrace.DNSStartInfo) { mark(&start.dns) },
+			DNSDone:           func(httptrace.DNSDoneInfo) { since(&t.DNS, &start.dns) },
+			ConnectStart:      func(string, string) { mark(&start.connect) },
+			ConnectDone:       func(string, string, error) { since(&t.Connect, &start.connect) },
+			TLSHandshakeStart: func() { mark(&start.tls) },
+			TLSHandshakeDone:  func(tls.ConnectionState, error) { since(&t.TLSHandshake, &start.tls) },
+			GotConn: func(info httptrace.GotConnInfo) {
+				mu.Lock()
+				t.ReusedConn = info.Reused
+				mu.Unlock()
+			},
+			WroteRequest:         func(httptrace.WroteRequestInfo) { mark(&start.wrote) },
+			GotFirstResponseByte: func() { since(&t.FirstByte, &start.wrote) },
+		}
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+		begin := time.Now()
+		resp, err := next(req)
+		mu.Lock()
+		t.Total = time.Since(begin)
+		timing := t
+		mu.Unlock()
+		record(op, req, timing)
+		return resp, err
+	}
+}
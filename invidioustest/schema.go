@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidioustest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+// AssertSchema reports an error for every member of the JSON fixture that T
+// lacks, and every non-optional member of T that the fixture lacks. See
+// invidious.DetectDrift.
+func AssertSchema[T any](t testing.TB, fixture []byte) {
+	t.Helper()
+	drift, err := invidious.DetectDrift(fixture, new(T))
+	if err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+	for _, path := range drift.Unknown {
+		t.Errorf("%s: unknown member %q", drift.Type, path)
+	}
+	for _, path := range drift.Missing {
+		t.Errorf("%s: missing member %q", drift.Type, path)
+	}
+}
+
+// AssertSchemaFile is like AssertSchema, but reads the fixture from a file.
+func AssertSchemaFile[T any](t testing.TB, name string) {
+	t.Helper()
+	fixture, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	AssertSchema[T](t, fixture)
+}
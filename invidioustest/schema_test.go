@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidioustest
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+func TestVideoResponseSchema(t *testing.T) {
+	AssertSchemaFile[invidious.VideoResponse](t, "testdata/video.json")
+}
+
+func TestDetectDriftRenamedMember(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/video.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The member that VideoResponse used to expect.
+	fixture = bytes.Replace(fixture, []byte(`"dashUrl"`), []byte(`"dashUr"`), 1)
+	drift, err := invidious.DetectDrift(fixture, new(invidious.VideoResponse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(drift.Unknown, []string{"dashUr"}) {
+		t.Errorf("Unknown = %q, want [dashUr]", drift.Unknown)
+	}
+	if !slices.Equal(drift.Missing, []string{"dashUrl"}) {
+		t.Errorf("Missing = %q, want [dashUrl]", drift.Missing)
+	}
+}
@@ -0,0 +1,328 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+// Package invidioustest provides an in-memory Invidious instance and schema
+// assertions for tests.
+package invidioustest
+
+import (
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/antoniszymanski/invidious-go"
+	"github.com/go-json-experiment/json"
+)
+
+// Server implements the authenticated endpoints of the Invidious API for a
+// single user. Its state may be inspected and modified while holding Mu.
+type Server struct {
+	*httptest.Server
+	Token string // expected bearer token
+
+	Mu            sync.Mutex
+	Subscriptions []Subscription
+	Playlists     []*Playlist
+	History       []string // oldest first
+	Preferences   invidious.PreferencesResponse
+	Feed          []invidious.FeedVideo
+	Stats         invidious.StatsResponse
+}
+
+type Subscription struct {
+	Author   string `json:"author"`
+	AuthorId string `json:"authorId"`
+}
+
+type Playlist struct {
+	Title       string
+	PlaylistId  string
+	Description string
+	Privacy     invidious.Privacy
+	Videos      []PlaylistVideo
+}
+
+type PlaylistVideo struct {
+	VideoId string
+	IndexId string
+}
+
+func NewServer() *Server {
+	s := &Server{Token: "token-" + rand.Text()}
+	s.Stats.Software.Name = "invidious"
+	s.Stats.Software.Version = "2.20250517.0-invidioustest"
+	s.Stats.Software.Branch = "master"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/stats", s.handleStats)
+	mux.HandleFunc("GET /api/v1/auth/feed", s.auth(s.handleFeed))
+	mux.HandleFunc("GET /api/v1/auth/subscriptions", s.auth(s.handleSubscriptions))
+	mux.HandleFunc("POST /api/v1/auth/subscriptions/{ucid}", s.auth(s.handleAddSubscription))
+	mux.HandleFunc("DELETE /api/v1/auth/subscriptions/{ucid}", s.auth(s.handleRemoveSubscription))
+	mux.HandleFunc("GET /api/v1/auth/playlists", s.auth(s.handlePlaylists))
+	mux.HandleFunc("POST /api/v1/auth/playlists", s.auth(s.handleCreatePlaylist))
+	mux.HandleFunc("GET /api/v1/auth/playlists/{id}", s.auth(s.handlePlaylist))
+	mux.HandleFunc("PATCH /api/v1/auth/playlists/{id}", s.auth(s.handleUpdatePlaylist))
+	mux.HandleFunc("DELETE /api/v1/auth/playlists/{id}", s.auth(s.handleDeletePlaylist))
+	mux.HandleFunc("POST /api/v1/auth/playlists/{id}/videos", s.auth(s.handleAddVideo))
+	mux.HandleFunc("DELETE /api/v1/auth/playlists/{id}/videos/{index}", s.auth(s.handleDeleteVideo))
+	mux.HandleFunc("GET /api/v1/auth/preferences", s.auth(s.handlePreferences))
+	mux.HandleFunc("POST /api/v1/auth/preferences", s.auth(s.handleUpdatePreferences))
+	mux.HandleFunc("GET /api/v1/auth/history", s.auth(s.handleHistory))
+	mux.HandleFunc("POST /api/v1/auth/history/{id}", s.auth(s.handleAddToHistory))
+	mux.HandleFunc("DELETE /api/v1/auth/history/{id}", s.auth(s.handleDeleteFromHistory))
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Client returns a client authenticated as the user of the server.
+func (s *Server) Client() *invidious.Client {
+	c := invidious.NewClient(s.URL)
+	c.RawToken = s.Token
+	c.HTTPClient = s.Server.Client()
+	return c
+}
+
+func (s *Server) auth(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.Token {
+			writeError(w, http.StatusForbidden, "Not authorized")
+			return
+		}
+		s.Mu.Lock()
+		defer s.Mu.Unlock()
+		h(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.MarshalWrite(w, v) //nolint:errcheck
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func (s *Server) handleStats(w http.ResponseWriter, _ *http.Request) {
+	s.Mu.Lock()
+	defer s.Mu.Unlock()
+	writeJSON(w, http.StatusOK, &s.Stats)
+}
+
+func page[T any](r *http.Request, items []T, defaultMaxResults int) []T {
+	maxResults, err := strconv.Atoi(r.URL.Query().Get("max_results"))
+	if err != nil || maxResults <= 0 {
+		maxResults = defaultMaxResults
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	start := min((page-1)*maxResults, len(items))
+	return items[start:min(start+maxResults, len(items))]
+}
+
+func (s *Server) handleFeed(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, &invidious.FeedResponse{
+		Notifications: []invidious.FeedVideo{},
+		Videos:        page(r, s.Feed, 60),
+	})
+}
+
+func (s *Server) handleSubscriptions(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, append([]Subscription{}, s.Subscriptions...))
+}
+
+func (s *Server) handleAddSubscription(w http.ResponseWriter, r *http.Request) {
+	ucid := r.PathValue("ucid")
+	if !slices.ContainsFunc(s.Subscriptions, func(sub Subscription) bool { return sub.AuthorId == ucid }) {
+		s.Subscriptions = append(s.Subscriptions, Subscription{Author: ucid, AuthorId: ucid})
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleRemoveSubscription(w http.ResponseWriter, r *http.Request) {
+	ucid := r.PathValue("ucid")
+	s.Subscriptions = slices.DeleteFunc(s.Subscriptions, func(sub Subscription) bool { return sub.AuthorId == ucid })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type playlistJSON struct {
+	Type        string      `json:"type"`
+	Title       string      `json:"title"`
+	PlaylistId  string      `json:"playlistId"`
+	Author      string      `json:"author"`
+	Description string      `json:"description"`
+	VideoCount  int32       `json:"videoCount"`
+	IsListed    bool        `json:"isListed"`
+	Videos      []videoJSON `json:"videos"`
+}
+
+type videoJSON struct {
+	Title   string `json:"title"`
+	VideoId string `json:"videoId"`
+	IndexId string `json:"indexId"`
+}
+
+func (p *Playlist) json() playlistJSON {
+	out := playlistJSON{
+		Type:        "invidiousPlaylist",
+		Title:       p.Title,
+		PlaylistId:  p.PlaylistId,
+		Author:      "invidioustest",
+		Description: p.Description,
+		VideoCount:  int32(len(p.Videos)),
+		IsListed:    p.Privacy == invidious.Public,
+		Videos:      make([]videoJSON, len(p.Videos)),
+	}
+	for i, v := range p.Videos {
+		out.Videos[i] = videoJSON{Title: v.VideoId, VideoId: v.VideoId, IndexId: v.IndexId}
+	}
+	return out
+}
+
+func (s *Server) playlist(w http.ResponseWriter, r *http.Request) *Playlist {
+	id := r.PathValue("id")
+	for _, p := range s.Playlists {
+		if p.PlaylistId == id {
+			return p
+		}
+	}
+	writeError(w, http.StatusNotFound, "Playlist does not exist.")
+	return nil
+}
+
+func (s *Server) handlePlaylists(w http.ResponseWriter, _ *http.Request) {
+	out := make([]playlistJSON, len(s.Playlists))
+	for i, p := range s.Playlists {
+		out[i] = p.json()
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleCreatePlaylist(w http.ResponseWriter, r *http.Request) {
+	var req invidious.CreatePlaylistRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil || req.Title == "" {
+		writeError(w, http.StatusBadRequest, "Invalid title.")
+		return
+	}
+	p := &Playlist{
+		Title:      req.Title,
+		PlaylistId: "IVPL" + rand.Text(),
+		Privacy:    req.Privacy,
+	}
+	s.Playlists = append(s.Playlists, p)
+	writeJSON(w, http.StatusCreated, &invidious.CreatePlaylistResponse{
+		Title:      p.Title,
+		PlaylistId: p.PlaylistId,
+	})
+}
+
+// handlePlaylist returns a page of up to 100 videos, like Invidious.
+func (s *Server) handlePlaylist(w http.ResponseWriter, r *http.Request) {
+	if p := s.playlist(w, r); p != nil {
+		out := p.json()
+		out.Videos = page(r, out.Videos, 100)
+		writeJSON(w, http.StatusOK, out)
+	}
+}
+
+func (s *Server) handleUpdatePlaylist(w http.ResponseWriter, r *http.Request) {
+	p := s.playlist(w, r)
+	if p == nil {
+		return
+	}
+	var req struct {
+		Title       *string            `json:"title"`
+		Description *string            `json:"description"`
+		Privacy     *invidious.Privacy `json:"privacy"`
+	}
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Title != nil {
+		p.Title = *req.Title
+	}
+	if req.Description != nil {
+		p.Description = *req.Description
+	}
+	if req.Privacy != nil {
+		p.Privacy = *req.Privacy
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDeletePlaylist(w http.ResponseWriter, r *http.Request) {
+	if p := s.playlist(w, r); p != nil {
+		s.Playlists = slices.DeleteFunc(s.Playlists, func(q *Playlist) bool { return q == p })
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) handleAddVideo(w http.ResponseWriter, r *http.Request) {
+	p := s.playlist(w, r)
+	if p == nil {
+		return
+	}
+	var req invidious.AddVideoRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil || req.VideoId == "" {
+		writeError(w, http.StatusBadRequest, "Invalid videoId")
+		return
+	}
+	v := PlaylistVideo{VideoId: req.VideoId, IndexId: strings.ToUpper(rand.Text()[:16])}
+	p.Videos = append(p.Videos, v)
+	writeJSON(w, http.StatusCreated, &videoJSON{Title: v.VideoId, VideoId: v.VideoId, IndexId: v.IndexId})
+}
+
+func (s *Server) handleDeleteVideo(w http.ResponseWriter, r *http.Request) {
+	p := s.playlist(w, r)
+	if p == nil {
+		return
+	}
+	index := r.PathValue("index")
+	i := slices.IndexFunc(p.Videos, func(v PlaylistVideo) bool { return v.IndexId == index })
+	if i < 0 {
+		writeError(w, http.StatusNotFound, "Playlist does not contain index")
+		return
+	}
+	p.Videos = slices.Delete(p.Videos, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handlePreferences(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &s.Preferences)
+}
+
+func (s *Server) handleUpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	if err := json.UnmarshalRead(r.Body, &s.Preferences); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	newestFirst := slices.Clone(s.History)
+	slices.Reverse(newestFirst)
+	writeJSON(w, http.StatusOK, append([]string{}, page(r, newestFirst, 100)...))
+}
+
+func (s *Server) handleAddToHistory(w http.ResponseWriter, r *http.Request) {
+	if id := r.PathValue("id"); !slices.Contains(s.History, id) {
+		s.History = append(s.History, id)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDeleteFromHistory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.History = slices.DeleteFunc(s.History, func(h string) bool { return h == id })
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"context"
+	"iter"
+
+	. "github.com/antoniszymanski/option-go"
+)
+
+type IterOptions struct {
+	MaxResults Option[int32] // page size
+	Prefetch   bool          // fetch the next page while the current one is consumed
+}
+
+// FeedAll iterates over the notifications and videos of every feed page.
+// Videos that shift between pages are yielded only once.
+func (c *Client) FeedAll(ctx context.Context, opts IterOptions) iter.Seq2[FeedVideo, error] {
+	return paginate(c, ctx, opts,
+		func(c *Client, page int32) ([]FeedVideo, error) {
+			resp, err := c.Feed(FeedRequest{
+				MaxResults: opts.MaxResults,
+				Page:       Some(page),
+			})
+			if err != nil {
+				return nil, err
+			}
+			// Notifications are repeated on every page.
+			if page > 1 {
+				return resp.Videos, nil
+			}
+			return append(resp.Notifications, resp.Videos...), nil
+		},
+		func(v FeedVideo) string { return v.VideoId },
+	)
+}
+
+// HistoryAll iterates over the IDs of every watched video.
+func (c *Client) HistoryAll(ctx context.Context, opts IterOptions) iter.Seq2[string, error] {
+	return paginate(c, ctx, opts,
+		func(c *Client, page int32) ([]string, error) {
+			return c.History(HistoryRequest{
+				MaxResults: opts.MaxResults,
+				Page:       Some(page),
+			})
+		},
+		func(id string) string { return id },
+	)
+}
+
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+func paginate[T any, K comparable](
+	c *Client,
+	ctx context.Context,
+	opts IterOptions,
+	fetch func(c *Client, page int32) ([]T, error),
+	key func(T) K,
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		c := c.WithContext(ctx)
+		if opts.Prefetch {
+			c.meta = nil // pages are fetched while the caller runs
+		}
+
+		fetchAsync := func(page int32) <-chan pageResult[T] {
+			ch := make(chan pageResult[T], 1)
+			go func() {
+				items, err := fetch(c, page)
+				ch <- pageResult[T]{items, err}
+			}()
+			return ch
+		}
+
+		seen := make(map[K]struct{})
+		var next <-chan pageResult[T]
+		for page := int32(1); ; page++ {
+			var items []T
+			var err error
+			if next != nil {
+				r := <-next
+				items, err, next = r.items, r.err, nil
+			} else if err = ctx.Err(); err == nil {
+				items, err = fetch(c, page)
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if len(items) == 0 {
+				return
+			}
+			if opts.Prefetch {
+				next = fetchAsync(page + 1)
+			}
+			fresh := 0
+			for _, item := range items {
+				k := key(item)
+				if _, ok := seen[k]; ok {
+					continue
+				}
+				seen[k] = struct{}{}
+				fresh++
+				if !yield(item, nil) {
+					return
+				}
+			}
+			// The server ignores the page or keeps repeating the same items.
+			if fresh == 0 {
+				return
+			}
+		}
+	}
+}
@@ -12,9 +12,10 @@
 func (c *Client) Stats() (*StatsResponse, error) {
 	var resp StatsResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/stats",
-		Output: &resp,
+		Operation: "Stats",
+		Method:    "GET",
+		Path:      "/api/v1/stats",
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -54,10 +55,11 @@ func (c *Client) Video(req VideoRequest) (*VideoResponse, error) {
 	}
 	var resp VideoResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/videos/" + req.Id,
-		Query:  query,
-		Output: &resp,
+		Operation: "Video",
+		Method:    "GET",
+		Path:      "/api/v1/videos/" + req.Id,
+		Query:     query,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -120,7 +122,7 @@ type VideoResponse struct {
 	LiveNow           bool                  `json:"liveNow"`
 	IsPostLiveDvr     bool                  `json:"isPostLiveDvr"`
 	IsUpcoming        bool                  `json:"isUpcoming"`
-	DashUr            string                `json:"dashUr"`
+	DashUrl           string                `json:"dashUrl"`
 	PremiereTimestamp option.Option[int64]  `json:"premiereTimestamp"`
 	HlsUrl            option.Option[string] `json:"hlsUrl"`
 	AdaptiveFormats   []struct {
@@ -161,7 +163,7 @@ type VideoResponse struct {
 	} `json:"formatStreams"`
 	Captions []struct {
 		Label         string `json:"label"`
-		Language_code string `json:"language_Code"`
+		Language_code string `json:"language_code"`
 		Url           string `json:"url"`
 	} `json:"captions"`
 	MusicTracks []struct {
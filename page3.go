@@ -8,9 +8,10 @@
 func (c *Client) Channel(id string) (*ChannelResponse, error) {
 	var resp ChannelResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/channels/" + id,
-		Output: &resp,
+		Operation: "Channel",
+		Method:    "GET",
+		Path:      "/api/v1/channels/" + id,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -69,11 +69,12 @@ func (c *Client) Feed(req FeedRequest) (*FeedResponse, error) {
 	})
 	var resp FeedResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/auth/feed",
-		Auth:   true,
-		Query:  query,
-		Output: &resp,
+		Operation: "Feed",
+		Method:    "GET",
+		Path:      "/api/v1/auth/feed",
+		Auth:      true,
+		Query:     query,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -86,51 +87,37 @@ type FeedRequest struct {
 }
 
 type FeedResponse struct {
-	Notifications []struct {
-		Type            string `json:"type"` // "shortVideo"
-		Title           string `json:"title"`
-		VideoId         string `json:"videoId"`
-		VideoThumbnails []struct {
-			Quality string `json:"quality"`
-			Url     string `json:"url"`
-			Width   int64  `json:"width"`
-			Height  int64  `json:"height"`
-		} `json:"videoThumbnails"`
-		LengthSeconds int64  `json:"lengthSeconds"`
-		Author        string `json:"author"`
-		AuthorId      string `json:"authorId"`
-		AuthorUrl     string `json:"authorUrl"`
-		Published     int64  `json:"published"`
-		PublishedText string `json:"publishedText"`
-		ViewCount     int64  `json:"viewCount"`
-	} `json:"notifications"`
-	Videos []struct {
-		Type            string `json:"type"` // "shortVideo"
-		Title           string `json:"title"`
-		VideoId         string `json:"videoId"`
-		VideoThumbnails []struct {
-			Quality string `json:"quality"`
-			Url     string `json:"url"`
-			Width   int64  `json:"width"`
-			Height  int64  `json:"height"`
-		} `json:"videoThumbnails"`
-		LengthSeconds int64  `json:"lengthSeconds"`
-		Author        string `json:"author"`
-		AuthorId      string `json:"authorId"`
-		AuthorUrl     string `json:"authorUrl"`
-		Published     int64  `json:"published"`
-		PublishedText string `json:"publishedText"`
-		ViewCount     int64  `json:"viewCount"`
-	} `json:"videos"`
+	Notifications []FeedVideo `json:"notifications"`
+	Videos        []FeedVideo `json:"videos"`
+}
+
+type FeedVideo struct {
+	Type            string `json:"type"` // "shortVideo"
+	Title           string `json:"title"`
+	VideoId         string `json:"videoId"`
+	VideoThumbnails []struct {
+		Quality string `json:"quality"`
+		Url     string `json:"url"`
+		Width   int64  `json:"width"`
+		Height  int64  `json:"height"`
+	} `json:"videoThumbnails"`
+	LengthSeconds int64  `json:"lengthSeconds"`
+	Author        string `json:"author"`
+	AuthorId      string `json:"authorId"`
+	AuthorUrl     string `json:"authorUrl"`
+	Published     int64  `json:"published"`
+	PublishedText string `json:"publishedText"`
+	ViewCount     int64  `json:"viewCount"`
 }
 
 func (c *Client) Playlists() (PlaylistsResponse, error) {
 	var resp PlaylistsResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/auth/playlists",
-		Auth:   true,
-		Output: &resp,
+		Operation: "Playlists",
+		Method:    "GET",
+		Path:      "/api/v1/auth/playlists",
+		Auth:      true,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -172,11 +159,12 @@ type PlaylistsResponse []struct {
 func (c *Client) CreatePlaylist(req CreatePlaylistRequest) (*CreatePlaylistResponse, error) {
 	var resp CreatePlaylistResponse
 	if err := c.call(&requestConfig{
-		Method: "POST",
-		Path:   "/api/v1/auth/playlists",
-		Auth:   true,
-		Input:  &req,
-		Output: &resp,
+		Operation: "CreatePlaylist",
+		Method:    "POST",
+		Path:      "/api/v1/auth/playlists",
+		Auth:      true,
+		Input:     &req,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -204,10 +192,28 @@ type CreatePlaylistResponse struct {
 func (c *Client) Playlist(id string) (*PlaylistResponse, error) {
 	var resp PlaylistResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/auth/playlists/" + id,
-		Auth:   true,
-		Output: &resp,
+		Operation: "Playlist",
+		Method:    "GET",
+		Path:      "/api/v1/auth/playlists/" + id,
+		Auth:      true,
+		Output:    &resp,
+	}); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PlaylistPage is like Playlist, but its response holds the given page of
+// videos. Pages start at 1 and hold up to 100 videos.
+func (c *Client) PlaylistPage(id string, page int32) (*PlaylistResponse, error) {
+	var resp PlaylistResponse
+	if err := c.call(&requestConfig{
+		Operation: "PlaylistPage",
+		Method:    "GET",
+		Path:      "/api/v1/auth/playlists/" + id,
+		Auth:      true,
+		Query:     url.Values{"page": {itoa(page)}},
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -230,6 +236,7 @@ type PlaylistResponse struct {
 	ViewCount       int64  `json:"viewCount"`
 	ViewCountText   string `json:"viewCountText"`
 	Updated         int64  `json:"updated"`
+	IsListed        bool   `json:"isListed"`
 	Videos          []struct {
 		Title           string `json:"title"`
 		VideoId         string `json:"videoId"`
@@ -250,10 +257,11 @@ type PlaylistResponse struct {
 
 func (c *Client) UpdatePlaylist(req UpdatePlaylistRequest) error {
 	return c.call(&requestConfig{
-		Method: "PATCH",
-		Path:   "/api/v1/auth/playlists/" + req.Id,
-		Auth:   true,
-		Input:  &req,
+		Operation: "UpdatePlaylist",
+		Method:    "PATCH",
+		Path:      "/api/v1/auth/playlists/" + req.Id,
+		Auth:      true,
+		Input:     &req,
 	})
 }
 
@@ -266,20 +274,22 @@ type UpdatePlaylistRequest struct {
 
 func (c *Client) DeletePlaylist(id string) error {
 	return c.call(&requestConfig{
-		Method: "DELETE",
-		Path:   "/api/v1/auth/playlists/" + id,
-		Auth:   true,
+		Operation: "DeletePlaylist",
+		Method:    "DELETE",
+		Path:      "/api/v1/auth/playlists/" + id,
+		Auth:      true,
 	})
 }
 
 func (c *Client) AddVideo(req AddVideoRequest) (*AddVideoResponse, error) {
 	var resp AddVideoResponse
 	if err := c.call(&requestConfig{
-		Method: "POST",
-		Path:   "/api/v1/auth/playlists/" + req.PlaylistId + "/videos",
-		Auth:   true,
-		Input:  &req,
-		Output: &resp,
+		Operation: "AddVideo",
+		Method:    "POST",
+		Path:      "/api/v1/auth/playlists/" + req.PlaylistId + "/videos",
+		Auth:      true,
+		Input:     &req,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -303,13 +313,16 @@ type AddVideoResponse struct {
 		Width   int32  `json:"width"`
 		Height  int32  `json:"height"`
 	} `json:"videoThumbnails"`
+	IndexId       string `json:"indexId"`
+	LengthSeconds int32  `json:"lengthSeconds"`
 }
 
 func (c *Client) DeleteVideo(req DeleteVideoRequest) error {
 	return c.call(&requestConfig{
-		Method: "DELETE",
-		Path:   "/api/v1/auth/playlists/" + req.PlaylistId + "/videos/" + req.IndexId,
-		Auth:   true,
+		Operation: "DeleteVideo",
+		Method:    "DELETE",
+		Path:      "/api/v1/auth/playlists/" + req.PlaylistId + "/videos/" + req.IndexId,
+		Auth:      true,
 	})
 }
 
@@ -321,10 +334,11 @@ type DeleteVideoRequest struct {
 func (c *Client) Preferences() (*PreferencesResponse, error) {
 	var resp PreferencesResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/auth/preferences",
-		Auth:   true,
-		Output: &resp,
+		Operation: "Preferences",
+		Method:    "GET",
+		Path:      "/api/v1/auth/preferences",
+		Auth:      true,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -359,16 +373,24 @@ type PreferencesResponse struct {
 	Volume                uint8    `json:"volume"`
 }
 
-// func (c *Client) UpdatePreferences() {
-// }
+func (c *Client) UpdatePreferences(req *PreferencesResponse) error {
+	return c.call(&requestConfig{
+		Operation: "UpdatePreferences",
+		Method:    "POST",
+		Path:      "/api/v1/auth/preferences",
+		Auth:      true,
+		Input:     req,
+	})
+}
 
 func (c *Client) Subscriptions() (SubscriptionsResponse, error) {
 	var resp SubscriptionsResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/auth/subscriptions",
-		Auth:   true,
-		Output: &resp,
+		Operation: "Subscriptions",
+		Method:    "GET",
+		Path:      "/api/v1/auth/subscriptions",
+		Auth:      true,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -382,46 +404,52 @@ type SubscriptionsResponse []struct {
 
 func (c *Client) AddSubscription(ucid string) error {
 	return c.call(&requestConfig{
-		Method: "POST",
-		Path:   "/api/v1/auth/subscriptions/" + ucid,
-		Auth:   true,
+		Operation: "AddSubscription",
+		Method:    "POST",
+		Path:      "/api/v1/auth/subscriptions/" + ucid,
+		Auth:      true,
 	})
 }
 
 func (c *Client) RemoveSubscription(ucid string) error {
 	return c.call(&requestConfig{
-		Method: "DELETE",
-		Path:   "/api/v1/auth/subscriptions/" + ucid,
-		Auth:   true,
+		Operation: "RemoveSubscription",
+		Method:    "DELETE",
+		Path:      "/api/v1/auth/subscriptions/" + ucid,
+		Auth:      true,
 	})
 }
 
 func (c *Client) Tokens() (TokensResponse, error) {
 	var resp TokensResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/auth/tokens",
-		Auth:   true,
-		Output: &resp,
+		Operation: "Tokens",
+		Method:    "GET",
+		Path:      "/api/v1/auth/tokens",
+		Auth:      true,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-type TokensResponse []struct {
-	Session string `json:"session"`
-	Issued  int64  `json:"issued"`
+type TokensResponse []Session
+
+type Session struct {
+	Session string    `json:"session"`
+	Issued  time.Time `json:"issued"`
 }
 
 func (c *Client) RegisterToken(req RegisterTokenRequest) (*Token, error) {
 	var resp Token
 	if err := c.call(&requestConfig{
-		Method: "POST",
-		Path:   "/api/v1/auth/tokens/register",
-		Auth:   true,
-		Input:  &req,
-		Output: &resp,
+		Operation: "RegisterToken",
+		Method:    "POST",
+		Path:      "/api/v1/auth/tokens/register",
+		Auth:      true,
+		Input:     &req,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -464,10 +492,11 @@ func ParseToken(in string) (*Token, error) {
 
 func (c *Client) RevokeToken(req RevokeRequest) error {
 	return c.call(&requestConfig{
-		Method: "POST",
-		Path:   "/api/v1/auth/tokens/unregister",
-		Auth:   true,
-		Input:  &req,
+		Operation: "RevokeToken",
+		Method:    "POST",
+		Path:      "/api/v1/auth/tokens/unregister",
+		Auth:      true,
+		Input:     &req,
 	})
 }
 
@@ -485,11 +514,12 @@ func (c *Client) History(req HistoryRequest) (HistoryResponse, error) {
 	})
 	var resp HistoryResponse
 	if err := c.call(&requestConfig{
-		Method: "GET",
-		Path:   "/api/v1/auth/history",
-		Auth:   true,
-		Query:  query,
-		Output: &resp,
+		Operation: "History",
+		Method:    "GET",
+		Path:      "/api/v1/auth/history",
+		Auth:      true,
+		Query:     query,
+		Output:    &resp,
 	}); err != nil {
 		return nil, err
 	}
@@ -505,16 +535,18 @@ type HistoryRequest struct {
 
 func (c *Client) AddToHistory(id string) error {
 	return c.call(&requestConfig{
-		Method: "POST",
-		Path:   "/api/v1/auth/history/" + id,
-		Auth:   true,
+		Operation: "AddToHistory",
+		Method:    "POST",
+		Path:      "/api/v1/auth/history/" + id,
+		Auth:      true,
 	})
 }
 
 func (c *Client) DeleteFromHistory(id string) error {
 	return c.call(&requestConfig{
-		Method: "DELETE",
-		Path:   "/api/v1/auth/history/" + id,
-		Auth:   true,
+		Operation: "DeleteFromHistory",
+		Method:    "DELETE",
+		Path:      "/api/v1/auth/history/" + id,
+		Auth:      true,
 	})
 }
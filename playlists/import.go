@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package playlists
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/antoniszymanski/invidious-go"
+	"github.com/antoniszymanski/option-go"
+)
+
+type ImportOptions struct {
+	// PlaylistId resumes a previous import into an existing playlist instead
+	// of creating a new one. Videos already in it are not added again.
+	PlaylistId string
+	// Concurrency bounds the parallel AddVideo calls (default: 1). The order
+	// of the videos is preserved only when it is 1.
+	Concurrency int
+}
+
+type ImportResult struct {
+	PlaylistId string // pass it as ImportOptions.PlaylistId to resume
+	Added      []string
+	Failed     []string
+}
+
+// Import creates the playlist and adds its videos. On partial failure the
+// returned result is non-nil and the import can be resumed.
+func Import(ctx context.Context, c *invidious.Client, pl *Playlist, opts ImportOptions) (*ImportResult, error) {
+	// The videos may be added concurrently.
+	c = c.WithContext(ctx).WithResponseMeta(nil)
+	result := ImportResult{PlaylistId: opts.PlaylistId}
+
+	// The number of occurrences of each video already in the playlist.
+	existing := make(map[string]int)
+	if result.PlaylistId == "" {
+		privacy := pl.Privacy
+		if privacy == "" {
+			privacy = invidious.Private
+		}
+		resp, err := c.CreatePlaylist(invidious.CreatePlaylistRequest{
+			Title:   pl.Title,
+			Privacy: privacy,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.PlaylistId = resp.PlaylistId
+		if pl.Description != "" {
+			if err = c.UpdatePlaylist(invidious.UpdatePlaylistRequest{
+				Id:          result.PlaylistId,
+				Description: option.Some(pl.Description),
+			}); err != nil {
+				return &result, err
+			}
+		}
+	} else {
+		resp, err := Fetch(c, result.PlaylistId)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range resp.Videos {
+			existing[v.VideoId]++
+		}
+	}
+
+	var pending []string
+	for _, v := range pl.Videos {
+		if existing[v.VideoId] > 0 {
+			existing[v.VideoId]--
+			continue
+		}
+		pending = append(pending, v.VideoId)
+	}
+
+	concurrency := max(opts.Concurrency, 1)
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+		sem  = make(chan struct{}, concurrency)
+	)
+	for _, id := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			_, err := c.AddVideo(invidious.AddVideoRequest{
+				PlaylistId: result.PlaylistId,
+				VideoId:    id,
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Failed = append(result.Failed, id)
+				errs = append(errs, err)
+			} else {
+				result.Added = append(result.Added, id)
+			}
+		})
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return &result, errors.Join(errs...)
+}
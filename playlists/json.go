@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package playlists
+
+import (
+	"io"
+	"strings"
+
+	"github.com/antoniszymanski/invidious-go"
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// The playlists member of the Invidious data export.
+type jsonExport struct {
+	Playlists []jsonPlaylist `json:"playlists"`
+}
+
+type jsonPlaylist struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Privacy     string   `json:"privacy"` // "Public"|"Unlisted"|"Private"
+	Videos      []string `json:"videos"`
+}
+
+// ReadJSON reads the playlists of an Invidious data export. Other members of
+// the export are ignored.
+func ReadJSON(r io.Reader) ([]Playlist, error) {
+	var export jsonExport
+	if err := json.UnmarshalRead(r, &export); err != nil {
+		return nil, err
+	}
+	pls := make([]Playlist, len(export.Playlists))
+	for i, p := range export.Playlists {
+		pls[i] = Playlist{
+			Title:       p.Title,
+			Description: p.Description,
+			Privacy:     invidious.Privacy(strings.ToLower(p.Privacy)),
+			Videos:      make([]Video, len(p.Videos)),
+		}
+		for j, id := range p.Videos {
+			pls[i].Videos[j] = Video{VideoId: id}
+		}
+	}
+	return pls, nil
+}
+
+func WriteJSON(w io.Writer, pls []Playlist) error {
+	export := jsonExport{Playlists: make([]jsonPlaylist, len(pls))}
+	for i, pl := range pls {
+		export.Playlists[i] = jsonPlaylist{
+			Title:       pl.Title,
+			Description: pl.Description,
+			Privacy:     privacyLabel(pl.Privacy),
+			Videos:      make([]string, len(pl.Videos)),
+		}
+		for j, v := range pl.Videos {
+			export.Playlists[i].Videos[j] = v.VideoId
+		}
+	}
+	return json.MarshalWrite(w, &export, jsontext.Multiline(true))
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package playlists
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func ReadM3U(r io.Reader) (*Playlist, error) {
+	var pl Playlist
+	var pending Video
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "", line == "#EXTM3U":
+		case strings.HasPrefix(line, "#PLAYLIST:"):
+			pl.Title = strings.TrimPrefix(line, "#PLAYLIST:")
+		case strings.HasPrefix(line, "#EXTINF:"):
+			length, title, _ := strings.Cut(strings.TrimPrefix(line, "#EXTINF:"), ",")
+			length, _, _ = strings.Cut(length, " ") // attributes
+			if n, err := strconv.ParseInt(length, 10, 32); err == nil && n > 0 {
+				pending.LengthSeconds = int32(n)
+			}
+			if author, t, ok := strings.Cut(title, " - "); ok {
+				pending.Author, pending.Title = author, t
+			} else {
+				pending.Title = title
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			if id := videoIdFromURL(line); id != "" {
+				pending.VideoId = id
+				pl.Videos = append(pl.Videos, pending)
+			}
+			pending = Video{}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return &pl, nil
+}
+
+func WriteM3U(w io.Writer, pl *Playlist) error {
+	var sb strings.Builder
+	sb.WriteString("#EXTM3U\n")
+	if pl.Title != "" {
+		sb.WriteString("#PLAYLIST:" + oneLine(pl.Title) + "\n")
+	}
+	for _, v := range pl.Videos {
+		length := int32(-1)
+		if v.LengthSeconds > 0 {
+			length = v.LengthSeconds
+		}
+		title := oneLine(v.Title)
+		if v.Author != "" {
+			title = oneLine(v.Author) + " - " + title
+		}
+		sb.WriteString("#EXTINF:" + strconv.FormatInt(int64(length), 10) + "," + title + "\n")
+		sb.WriteString(watchURL(v.VideoId) + "\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,317 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package playlists
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+type ChangeKind uint8
+
+const (
+	AddedVideo ChangeKind = iota + 1
+	DeletedVideo
+	CreatedPlaylist
+)
+
+type Change struct {
+	Kind       ChangeKind
+	PlaylistId string
+	VideoId    string
+	IndexId    string
+}
+
+// Log records the changes made by an operation, so that they can be rolled
+// back if it fails partway.
+type Log []Change
+
+// Rollback reverts the changes in reverse order. Deleted videos are appended
+// again, so their position isn't restored.
+func (l Log) Rollback(ctx context.Context, c *invidious.Client) error {
+	c = c.WithContext(ctx)
+	var errs []error
+	for i := len(l) - 1; i >= 0; i-- {
+		var err error
+		switch ch := l[i]; ch.Kind {
+		case AddedVideo:
+			err = c.DeleteVideo(invidious.DeleteVideoRequest{
+				PlaylistId: ch.PlaylistId,
+				IndexId:    ch.IndexId,
+			})
+		case DeletedVideo:
+			_, err = c.AddVideo(invidious.AddVideoRequest{
+				PlaylistId: ch.PlaylistId,
+				VideoId:    ch.VideoId,
+			})
+		case CreatedPlaylist:
+			err = c.DeletePlaylist(ch.PlaylistId)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type editor struct {
+	c   *invidious.Client
+	log Log
+}
+
+func newEditor(ctx context.Context, c *invidious.Client) *editor {
+	return &editor{c: c.WithContext(ctx)}
+}
+
+func (e *editor) add(playlistId, videoId string) error {
+	resp, err := e.c.AddVideo(invidious.AddVideoRequest{
+		PlaylistId: playlistId,
+		VideoId:    videoId,
+	})
+	if err != nil {
+		return err
+	}
+	e.log = append(e.log, Change{
+		Kind:       AddedVideo,
+		PlaylistId: playlistId,
+		VideoId:    videoId,
+		IndexId:    resp.IndexId,
+	})
+	return nil
+}
+
+func (e *editor) delete(playlistId, indexId, videoId string) error {
+	if err := e.c.DeleteVideo(invidious.DeleteVideoRequest{
+		PlaylistId: playlistId,
+		IndexId:    indexId,
+	}); err != nil {
+		return err
+	}
+	e.log = append(e.log, Change{
+		Kind:       DeletedVideo,
+		PlaylistId: playlistId,
+		VideoId:    videoId,
+		IndexId:    indexId,
+	})
+	return nil
+}
+
+func (e *editor) create(title string, privacy invidious.Privacy) (string, error) {
+	if privacy == "" {
+		privacy = invidious.Private
+	}
+	resp, err := e.c.CreatePlaylist(invidious.CreatePlaylistRequest{
+		Title:   title,
+		Privacy: privacy,
+	})
+	if err != nil {
+		return "", err
+	}
+	e.log = append(e.log, Change{Kind: CreatedPlaylist, PlaylistId: resp.PlaylistId})
+	return resp.PlaylistId, nil
+}
+
+// Dedupe removes every occurrence of a video except the first one.
+func Dedupe(ctx context.Context, c *invidious.Client, playlistId string) (Log, error) {
+	e := newEditor(ctx, c)
+	pl, err := Fetch(e.c, playlistId)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{}, len(pl.Videos))
+	for _, v := range pl.Videos {
+		if _, ok := seen[v.VideoId]; !ok {
+			seen[v.VideoId] = struct{}{}
+			continue
+		}
+		if err = e.delete(playlistId, v.IndexId, v.VideoId); err != nil {
+			return e.log, err
+		}
+	}
+	return e.log, nil
+}
+
+// Copy appends a video to another playlist.
+func Copy(ctx context.Context, c *invidious.Client, videoId, toPlaylistId string) (Log, error) {
+	e := newEditor(ctx, c)
+	err := e.add(toPlaylistId, videoId)
+	return e.log, err
+}
+
+// Move appends the video identified by indexId to another playlist and
+// removes it from its current one.
+func Move(ctx context.Context, c *invidious.Client, fromPlaylistId, indexId, toPlaylistId string) (Log, error) {
+	e := newEditor(ctx, c)
+	pl, err := Fetch(e.c, fromPlaylistId)
+	if err != nil {
+		return nil, err
+	}
+	var videoId string
+	for _, v := range pl.Videos {
+		if v.IndexId == indexId {
+			videoId = v.VideoId
+			break
+		}
+	}
+	if videoId == "" {
+		return nil, errors.New("video " + strconv.Quote(indexId) + " not found in playlist " + fromPlaylistId)
+	}
+	if err = e.add(toPlaylistId, videoId); err != nil {
+		return e.log, err
+	}
+	err = e.delete(fromPlaylistId, indexId, videoId)
+	return e.log, err
+}
+
+// Merge creates a playlist with the videos of the given playlists, in order,
+// skipping duplicates. The source playlists are left unchanged.
+func Merge(ctx context.Context, c *invidious.Client, title string, privacy invidious.Privacy, playlistIds ...string) (string, Log, error) {
+	e := newEditor(ctx, c)
+	var videoIds []string
+	seen := make(map[string]struct{})
+	for _, id := range playlistIds {
+		pl, err := Fetch(e.c, id)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, v := range pl.Videos {
+			if _, ok := seen[v.VideoId]; !ok {
+				seen[v.VideoId] = struct{}{}
+				videoIds = append(videoIds, v.VideoId)
+			}
+		}
+	}
+	id, err := e.fill(title, privacy, videoIds)
+	return id, e.log, err
+}
+
+// SplitBySize creates playlists of at most size videos each, titled
+// "<title> (<n>)". The source playlist is left unchanged.
+func SplitBySize(ctx context.Context, c *invidious.Client, playlistId string, size int) ([]string, Log, error) {
+	if size <= 0 {
+		return nil, nil, errors.New("invalid split size: " + strconv.Itoa(size))
+	}
+	e := newEditor(ctx, c)
+	pl, err := Fetch(e.c, playlistId)
+	if err != nil {
+		return nil, nil, err
+	}
+	privacy := privacyOf(pl.IsListed)
+	var ids []string
+	for n, i := 1, 0; i < len(pl.Videos); n, i = n+1, i+size {
+		chunk := pl.Videos[i:min(i+size, len(pl.Videos))]
+		videoIds := make([]string, len(chunk))
+		for j, v := range chunk {
+			videoIds[j] = v.VideoId
+		}
+		id, err := e.fill(pl.Title+" ("+strconv.Itoa(n)+")", privacy, videoIds)
+		if err != nil {
+			return ids, e.log, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, e.log, nil
+}
+
+// SplitByAuthor creates a playlist titled "<title> - <author>" for every
+// author in the playlist. The source playlist is left unchanged.
+func SplitByAuthor(ctx context.Context, c *invidious.Client, playlistId string) (map[string]string, Log, error) {
+	e := newEditor(ctx, c)
+	pl, err := Fetch(e.c, playlistId)
+	if err != nil {
+		return nil, nil, err
+	}
+	var authors []string
+	byAuthor := make(map[string][]string)
+	for _, v := range pl.Videos {
+		if _, ok := byAuthor[v.Author]; !ok {
+			authors = append(authors, v.Author)
+		}
+		byAuthor[v.Author] = append(byAuthor[v.Author], v.VideoId)
+	}
+	privacy := privacyOf(pl.IsListed)
+	ids := make(map[string]string, len(authors))
+	for _, author := range authors {
+		id, err := e.fill(pl.Title+" - "+author, privacy, byAuthor[author])
+		if err != nil {
+			return ids, e.log, err
+		}
+		ids[author] = id
+	}
+	return ids, e.log, nil
+}
+
+// RemoveUnavailable removes the videos that Invidious reports as unavailable,
+// e.g. deleted or private ones. Other errors, including rate limiting and
+// server errors, abort the operation.
+func RemoveUnavailable(ctx context.Context, c *invidious.Client, playlistId string) (Log, error) {
+	e := newEditor(ctx, c)
+	pl, err := Fetch(e.c, playlistId)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range pl.Videos {
+		_, err = e.c.Video(invidious.VideoRequest{Id: v.VideoId})
+		if err == nil {
+			continue
+		} else if !isUnavailable(err) {
+			return e.log, err
+		}
+		if err = e.delete(playlistId, v.IndexId, v.VideoId); err != nil {
+			return e.log, err
+		}
+	}
+	return e.log, nil
+}
+
+// unavailableMessages are the parts of the error messages that Invidious
+// returns with status 500 for videos that can't be played.
+var unavailableMessages = []string{
+	"video unavailable",
+	"video is unavailable",
+	"video is not available",
+	"video is private",
+	"has been removed",
+	"has been terminated",
+	"no longer available",
+}
+
+// isUnavailable reports whether err is the error returned by Client.Video for
+// a dead video: 404, or 500 with one of unavailableMessages.
+func isUnavailable(err error) bool {
+	var apiErr invidious.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound:
+		return true
+	case http.StatusInternalServerError:
+		message := strings.ToLower(apiErr.Message)
+		return slices.ContainsFunc(unavailableMessages, func(s string) bool {
+			return strings.Contains(message, s)
+		})
+	default:
+		return false
+	}
+}
+
+func (e *editor) fill(title string, privacy invidious.Privacy, videoIds []string) (string, error) {
+	id, err := e.create(title, privacy)
+	if err != nil {
+		return "", err
+	}
+	for _, videoId := range videoIds {
+		if err = e.add(id, videoId); err != nil {
+			return id, err
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+// Package playlists backs up and restores playlists in common file formats.
+package playlists
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+type Playlist struct {
+	Title       string
+	Description string
+	Privacy     invidious.Privacy
+	Videos      []Video
+}
+
+type Video struct {
+	VideoId       string
+	Title         string
+	Author        string
+	LengthSeconds int32
+}
+
+type Format uint8
+
+const (
+	Takeout Format = iota + 1 // Google Takeout CSV
+	M3U                       // extended M3U
+	XSPF                      // XML Shareable Playlist Format
+	JSON                      // Invidious data export
+)
+
+func (f Format) String() string {
+	switch f {
+	case Takeout:
+		return "Takeout"
+	case M3U:
+		return "M3U"
+	case XSPF:
+		return "XSPF"
+	case JSON:
+		return "JSON"
+	default:
+		return "Format(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+var (
+	ErrUnknownFormat     = errors.New("unknown playlist format")
+	ErrMultiplePlaylists = errors.New("format holds a single playlist")
+	// ErrIncomplete is returned when fewer videos of a playlist were fetched
+	// than it reports having.
+	ErrIncomplete = errors.New("incomplete playlist")
+)
+
+// FormatOf guesses the format from the extension of a file name.
+func FormatOf(name string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return Takeout, nil
+	case ".m3u", ".m3u8":
+		return M3U, nil
+	case ".xspf":
+		return XSPF, nil
+	case ".json":
+		return JSON, nil
+	default:
+		return 0, ErrUnknownFormat
+	}
+}
+
+func Read(r io.Reader, f Format) ([]Playlist, error) {
+	var pl *Playlist
+	var err error
+	switch f {
+	case Takeout:
+		pl, err = ReadTakeout(r)
+	case M3U:
+		pl, err = ReadM3U(r)
+	case XSPF:
+		pl, err = ReadXSPF(r)
+	case JSON:
+		return ReadJSON(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []Playlist{*pl}, nil
+}
+
+// Write writes the playlists to w. All formats except JSON hold exactly one
+// playlist.
+func Write(w io.Writer, f Format, pls []Playlist) error {
+	if f == JSON {
+		return WriteJSON(w, pls)
+	}
+	if len(pls) != 1 {
+		return ErrMultiplePlaylists
+	}
+	switch f {
+	case Takeout:
+		return WriteTakeout(w, &pls[0])
+	case M3U:
+		return WriteM3U(w, &pls[0])
+	case XSPF:
+		return WriteXSPF(w, &pls[0])
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// Fetch fetches a playlist of the authenticated user with all of its videos,
+// which the API returns in pages.
+func Fetch(c *invidious.Client, id string) (*invidious.PlaylistResponse, error) {
+	resp, err := c.PlaylistPage(id, 1)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{}, resp.VideoCount)
+	for _, v := range resp.Videos {
+		seen[v.IndexId] = struct{}{}
+	}
+	for page := int32(2); len(resp.Videos) < int(resp.VideoCount); page++ {
+		next, err := c.PlaylistPage(id, page)
+		if err != nil {
+			return nil, err
+		}
+		fresh := 0
+		for _, v := range next.Videos {
+			if _, ok := seen[v.IndexId]; !ok {
+				seen[v.IndexId] = struct{}{}
+				resp.Videos = append(resp.Videos, v)
+				fresh++
+			}
+		}
+		// The server ignores the page, or the playlist shrank meanwhile.
+		if fresh == 0 {
+			break
+		}
+	}
+	if err = checkComplete(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func checkComplete(resp *invidious.PlaylistResponse) error {
+	if len(resp.Videos) < int(resp.VideoCount) {
+		return fmt.Errorf("%w: %s has %d videos, got %d", ErrIncomplete,
+			resp.PlaylistId, resp.VideoCount, len(resp.Videos))
+	}
+	return nil
+}
+
+// Export fetches a playlist of the authenticated user with all of its videos.
+func Export(c *invidious.Client, id string) (*Playlist, error) {
+	resp, err := Fetch(c, id)
+	if err != nil {
+		return nil, err
+	}
+	pl := Playlist{
+		Title:       resp.Title,
+		Description: resp.Description,
+		Privacy:     privacyOf(resp.IsListed),
+		Videos:      make([]Video, len(resp.Videos)),
+	}
+	for i, v := range resp.Videos {
+		pl.Videos[i] = Video{
+			VideoId:       v.VideoId,
+			Title:         v.Title,
+			Author:        v.Author,
+			LengthSeconds: v.LengthSeconds,
+		}
+	}
+	return &pl, nil
+}
+
+// ExportAll fetches every playlist of the authenticated user. It fails with
+// ErrIncomplete if a playlist has fewer videos than the listing reports.
+func ExportAll(c *invidious.Client) ([]Playlist, error) {
+	resp, err := c.Playlists()
+	if err != nil {
+		return nil, err
+	}
+	pls := make([]Playlist, len(resp))
+	for i, p := range resp {
+		pl, err := Export(c, p.PlaylistId)
+		if err != nil {
+			return nil, err
+		}
+		if len(pl.Videos) < int(p.VideoCount) {
+			return nil, fmt.Errorf("%w: %s has %d videos, got %d", ErrIncomplete,
+				p.PlaylistId, p.VideoCount, len(pl.Videos))
+		}
+		pl.Privacy = privacyOf(p.IsListed)
+		pls[i] = *pl
+	}
+	return pls, nil
+}
+
+// The API only reports whether a playlist is listed.
+func privacyOf(isListed bool) invidious.Privacy {
+	if isListed {
+		return invidious.Public
+	}
+	return invidious.Private
+}
+
+// privacyLabel returns the capitalized form used by exports.
+func privacyLabel(p invidious.Privacy) string {
+	if p == "" {
+		return ""
+	}
+	return strings.ToUpper(string(p[:1])) + string(p[1:])
+}
+
+func watchURL(id string) string {
+	return "https://www.youtube.com/watch?v=" + id
+}
+
+// videoIdFromURL extracts the video ID from a watch URL.
+func videoIdFromURL(s string) string {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return ""
+	}
+	if id := u.Query().Get("v"); id != "" {
+		return id
+	}
+	if strings.HasSuffix(u.Host, "youtu.be") {
+		return strings.Trim(u.Path, "/")
+	}
+	for _, prefix := range []string{"/embed/", "/shorts/", "/v/"} {
+		if id, ok := strings.CutPrefix(u.Path, prefix); ok {
+			id, _, _ = strings.Cut(id, "/")
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package playlists
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/antoniszymanski/invidious-go"
+	. "github.com/antoniszymanski/option-go"
+)
+
+// SmartPlaylist fills a playlist with the videos from the feed and from the
+// latest videos of channels that match its rules.
+type SmartPlaylist struct {
+	PlaylistId string
+
+	FromFeed  bool     // evaluate the feed of the authenticated user
+	FeedLimit int      // maximum number of feed videos evaluated (default: 200)
+	Channels  []string // evaluate the latest videos of these channels
+
+	AllowChannels  []string // if non-empty, only these channel IDs match
+	DenyChannels   []string
+	TitlePattern   string        // regular expression matched against the title
+	KeywordPattern string        // regular expression matched against the title and description
+	MinLength      Option[int64] // seconds
+	MaxLength      Option[int64] // seconds
+	PublishedAfter time.Time
+	// ExcludeShorts excludes shorts. The feed marks them; videos of channel
+	// listings are taken as shorts if not longer than ShortMaxLength.
+	ExcludeShorts  bool
+	ShortMaxLength int64 // seconds (default: 60)
+	ExcludeWatched bool  // exclude videos in the watch history
+
+	// MaxSize trims the playlist by removing its oldest entries (0: no limit).
+	MaxSize int
+}
+
+type SmartResult struct {
+	Added   []string
+	Removed []string
+	Log     Log
+}
+
+type candidate struct {
+	VideoId       string
+	Title         string
+	Description   string
+	AuthorId      string
+	LengthSeconds int64
+	Published     time.Time
+	Short         Option[bool] // None if the source doesn't tell
+}
+
+func (v *candidate) isShort(maxLength int64) bool {
+	if v.Short.IsSome() {
+		return v.Short.Unwrap()
+	}
+	return v.LengthSeconds > 0 && v.LengthSeconds <= maxLength
+}
+
+// Run appends the matching videos newer than those already in the playlist,
+// oldest first, and trims the playlist to MaxSize. Running it again without
+// new videos changes nothing.
+func (s *SmartPlaylist) Run(ctx context.Context, c *invidious.Client) (*SmartResult, error) {
+	var titleRe, keywordRe *regexp.Regexp
+	var err error
+	if s.TitlePattern != "" {
+		if titleRe, err = regexp.Compile(s.TitlePattern); err != nil {
+			return nil, err
+		}
+	}
+	if s.KeywordPattern != "" {
+		if keywordRe, err = regexp.Compile(s.KeywordPattern); err != nil {
+			return nil, err
+		}
+	}
+
+	e := newEditor(ctx, c)
+	pl, err := Fetch(e.c, s.PlaylistId)
+	if err != nil {
+		return nil, err
+	}
+	inPlaylist := make(map[string]struct{}, len(pl.Videos))
+	for _, v := range pl.Videos {
+		inPlaylist[v.VideoId] = struct{}{}
+	}
+	exclude := make(map[string]struct{})
+	if s.ExcludeWatched {
+		for id, err := range e.c.HistoryAll(ctx, invidious.IterOptions{}) {
+			if err != nil {
+				return nil, err
+			}
+			exclude[id] = struct{}{}
+		}
+	}
+
+	candidates, err := s.candidates(ctx, e.c)
+	if err != nil {
+		return nil, err
+	}
+	shortMaxLength := s.ShortMaxLength
+	if shortMaxLength <= 0 {
+		shortMaxLength = 60
+	}
+	// Matches are appended oldest first, so candidates older than the newest
+	// one already in the playlist were either added before or trimmed.
+	var watermark time.Time
+	for _, v := range candidates {
+		if _, ok := inPlaylist[v.VideoId]; ok && v.Published.After(watermark) {
+			watermark = v.Published
+		}
+	}
+	var matches []candidate
+	for _, v := range candidates {
+		if _, ok := inPlaylist[v.VideoId]; ok || !v.Published.After(watermark) {
+			continue
+		}
+		if _, ok := exclude[v.VideoId]; ok {
+			continue
+		}
+		exclude[v.VideoId] = struct{}{}
+		switch {
+		case len(s.AllowChannels) > 0 && !slices.Contains(s.AllowChannels, v.AuthorId),
+			slices.Contains(s.DenyChannels, v.AuthorId),
+			titleRe != nil && !titleRe.MatchString(v.Title),
+			keywordRe != nil && !keywordRe.MatchString(v.Title) && !keywordRe.MatchString(v.Description),
+			s.MinLength.IsSomeAnd(func(n int64) bool { return v.LengthSeconds < n }),
+			s.MaxLength.IsSomeAnd(func(n int64) bool { return v.LengthSeconds > n }),
+			!s.PublishedAfter.IsZero() && !v.Published.After(s.PublishedAfter),
+			s.ExcludeShorts && v.isShort(shortMaxLength):
+			continue
+		}
+		matches = append(matches, v)
+	}
+	slices.SortStableFunc(matches, func(a, b candidate) int {
+		return a.Published.Compare(b.Published)
+	})
+
+	var result SmartResult
+	if s.MaxSize > 0 {
+		if len(matches) > s.MaxSize {
+			matches = matches[len(matches)-s.MaxSize:]
+		}
+		overflow := max(len(pl.Videos)+len(matches)-s.MaxSize, 0)
+		for _, v := range pl.Videos[:overflow] {
+			if err = e.delete(s.PlaylistId, v.IndexId, v.VideoId); err != nil {
+				result.Log = e.log
+				return &result, err
+			}
+			result.Removed = append(result.Removed, v.VideoId)
+		}
+	}
+	for _, v := range matches {
+		if err = e.add(s.PlaylistId, v.VideoId); err != nil {
+			result.Log = e.log
+			return &result, err
+		}
+		result.Added = append(result.Added, v.VideoId)
+	}
+	result.Log = e.log
+	return &result, nil
+}
+
+func (s *SmartPlaylist) candidates(ctx context.Context, c *invidious.Client) ([]candidate, error) {
+	var candidates []candidate
+	if s.FromFeed {
+		limit := s.FeedLimit
+		if limit <= 0 {
+			limit = 200
+		}
+		for v, err := range c.FeedAll(ctx, invidious.IterOptions{}) {
+			if err != nil {
+				return nil, err
+			}
+			// The feed is sorted newest first.
+			if limit == 0 || !s.PublishedAfter.IsZero() && time.Unix(v.Published, 0).Before(s.PublishedAfter) {
+				break
+			}
+			limit--
+			candidates = append(candidates, candidate{
+				VideoId:       v.VideoId,
+				Title:         v.Title,
+				AuthorId:      v.AuthorId,
+				LengthSeconds: v.LengthSeconds,
+				Published:     time.Unix(v.Published, 0),
+				Short:         Some(v.Type == "shortVideo"),
+			})
+		}
+	}
+	for _, id := range s.Channels {
+		ch, err := c.Channel(id)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range ch.LatestVideos {
+			candidates = append(candidates, candidate{
+				VideoId:       v.VideoId,
+				Title:         v.Title,
+				Description:   v.Description,
+				AuthorId:      v.AuthorId,
+				LengthSeconds: v.LengthSeconds,
+				Published:     v.Published,
+			})
+		}
+	}
+	return candidates, nil
+}
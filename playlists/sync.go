@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package playlists
+
+import (
+	"context"
+
+	"github.com/antoniszymanski/invidious-go"
+	. "github.com/antoniszymanski/option-go"
+)
+
+type SyncOptions struct {
+	Title       Option[string]
+	Description Option[string]
+	// The API only reports whether a playlist is listed, so a change between
+	// Unlisted and Private isn't detected.
+	Privacy Option[invidious.Privacy]
+	DryRun  bool // compute the plan without applying it
+}
+
+// Plan is the minimal set of calls that turns a playlist into the desired one.
+type Plan struct {
+	PlaylistId string
+	Update     Option[invidious.UpdatePlaylistRequest]
+	Delete     []PlannedDelete
+	Add        []string // video IDs, appended in order
+}
+
+type PlannedDelete struct {
+	IndexId string
+	VideoId string
+}
+
+func (p *Plan) IsEmpty() bool {
+	return p.Update.IsNone() && len(p.Delete) == 0 && len(p.Add) == 0
+}
+
+// SyncPlaylist makes the videos of a playlist equal to desiredVideoIds, in
+// order, and updates its metadata. Since videos can only be appended, the
+// longest prefix of desiredVideoIds that is already in the playlist, in order,
+// is kept and the remaining suffix is re-appended.
+func SyncPlaylist(ctx context.Context, c *invidious.Client, playlistId string, desiredVideoIds []string, opts SyncOptions) (*Plan, error) {
+	resp, err := Fetch(c.WithContext(ctx), playlistId)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := PlanSync(resp, desiredVideoIds, opts)
+	if err != nil || opts.DryRun {
+		return plan, nil
+	}
+	return plan, plan.Apply(ctx, c)
+}
+
+// PlanSync computes the plan of SyncPlaylist for the current playlist, which
+// must hold all of its videos, see Fetch.
+func PlanSync(current *invidious.PlaylistResponse, desiredVideoIds []string, opts SyncOptions) (*Plan, error) {
+	if err := checkComplete(current); err != nil {
+		return nil, err
+	}
+	plan := Plan{PlaylistId: current.PlaylistId}
+
+	update := invidious.UpdatePlaylistRequest{Id: current.PlaylistId}
+	if opts.Title.IsSomeAnd(func(t string) bool { return t != current.Title }) {
+		update.Title = opts.Title
+	}
+	if opts.Description.IsSomeAnd(func(d string) bool { return d != current.Description }) {
+		update.Description = opts.Description
+	}
+	if opts.Privacy.IsSomeAnd(func(p invidious.Privacy) bool {
+		return (p == invidious.Public) != current.IsListed
+	}) {
+		update.Privacy = opts.Privacy
+	}
+	if update.Title.IsSome() || update.Description.IsSome() || update.Privacy.IsSome() {
+		plan.Update = Some(update)
+	}
+
+	// Greedily match the longest prefix of the desired videos that is a
+	// subsequence of the current ones.
+	kept := make([]bool, len(current.Videos))
+	k, j := 0, 0
+	for ; k < len(desiredVideoIds); k++ {
+		for j < len(current.Videos) && current.Videos[j].VideoId != desiredVideoIds[k] {
+			j++
+		}
+		if j == len(current.Videos) {
+			break
+		}
+		kept[j] = true
+		j++
+	}
+	for i, v := range current.Videos {
+		if !kept[i] {
+			plan.Delete = append(plan.Delete, PlannedDelete{IndexId: v.IndexId, VideoId: v.VideoId})
+		}
+	}
+	plan.Add = desiredVideoIds[k:]
+	return &plan, nil
+}
+
+// Apply performs the calls of the plan, stopping at the first error.
+func (p *Plan) Apply(ctx context.Context, c *invidious.Client) error {
+	c = c.WithContext(ctx)
+	if p.Update.IsSome() {
+		if err := c.UpdatePlaylist(p.Update.Unwrap()); err != nil {
+			return err
+		}
+	}
+	for _, d := range p.Delete {
+		if err := c.DeleteVideo(invidious.DeleteVideoRequest{
+			PlaylistId: p.PlaylistId,
+			IndexId:    d.IndexId,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, id := range p.Add {
+		if _, err := c.AddVideo(invidious.AddVideoRequest{
+			PlaylistId: p.PlaylistId,
+			VideoId:    id,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
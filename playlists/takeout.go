@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package playlists
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+// ReadTakeout reads both the legacy Takeout layout, which starts with a
+// playlist metadata section, and the current "<title>-videos.csv" layout.
+func ReadTakeout(r io.Reader) (*Playlist, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	var pl Playlist
+	inVideos := false
+	for len(records) > 0 {
+		rec := records[0]
+		records = records[1:]
+		header := strings.ToLower(strings.TrimPrefix(rec[0], "\ufeff"))
+		switch {
+		case header == "playlist id" && len(records) > 0:
+			meta := records[0]
+			records = records[1:]
+			for i, name := range rec {
+				if i >= len(meta) {
+					break
+				}
+				switch strings.ToLower(name) {
+				case "title":
+					pl.Title = meta[i]
+				case "description":
+					pl.Description = meta[i]
+				case "visibility":
+					pl.Privacy = invidious.Privacy(strings.ToLower(meta[i]))
+				}
+			}
+		case header == "video id":
+			inVideos = true
+		case inVideos && rec[0] != "":
+			pl.Videos = append(pl.Videos, Video{VideoId: strings.TrimSpace(rec[0])})
+		}
+	}
+	if !inVideos {
+		return nil, errors.New("invalid Takeout CSV: missing video section")
+	}
+	return &pl, nil
+}
+
+// WriteTakeout writes the legacy Takeout layout, which is the one accepted by
+// the Invidious playlist import.
+func WriteTakeout(w io.Writer, pl *Playlist) error {
+	cw := csv.NewWriter(w)
+	records := [][]string{
+		{"Playlist ID", "Channel ID", "Time Created", "Time Updated", "Title", "Description", "Visibility"},
+		{"", "", "", "", pl.Title, pl.Description, privacyLabel(pl.Privacy)},
+		{""},
+		{"Video ID", "Time Added"},
+	}
+	for _, v := range pl.Videos {
+		records = append(records, []string{v.VideoId, ""})
+	}
+	return cw.WriteAll(records)
+}
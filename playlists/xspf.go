@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package playlists
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+type xspfPlaylist struct {
+	XMLName    xml.Name    `xml:"http://xspf.org/ns/0/ playlist"`
+	Version    string      `xml:"version,attr"`
+	Title      string      `xml:"title,omitempty"`
+	Annotation string      `xml:"annotation,omitempty"`
+	Tracks     []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location string `xml:"location"`
+	Title    string `xml:"title,omitempty"`
+	Creator  string `xml:"creator,omitempty"`
+	Duration int64  `xml:"duration,omitempty"` // milliseconds
+}
+
+func ReadXSPF(r io.Reader) (*Playlist, error) {
+	var doc xspfPlaylist
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	pl := Playlist{Title: doc.Title, Description: doc.Annotation}
+	for _, t := range doc.Tracks {
+		id := videoIdFromURL(t.Location)
+		if id == "" {
+			continue
+		}
+		pl.Videos = append(pl.Videos, Video{
+			VideoId:       id,
+			Title:         t.Title,
+			Author:        t.Creator,
+			LengthSeconds: int32(t.Duration / 1000),
+		})
+	}
+	return &pl, nil
+}
+
+func WriteXSPF(w io.Writer, pl *Playlist) error {
+	doc := xspfPlaylist{
+		Version:    "1",
+		Title:      pl.Title,
+		Annotation: pl.Description,
+		Tracks:     make([]xspfTrack, len(pl.Videos)),
+	}
+	for i, v := range pl.Videos {
+		doc.Tracks[i] = xspfTrack{
+			Location: watchURL(v.VideoId),
+			Title:    v.Title,
+			Creator:  v.Author,
+			Duration: int64(v.LengthSeconds) * 1000,
+		}
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type EndpointClass string
+
+const (
+	ClassSearch EndpointClass = "search"
+	ClassVideos EndpointClass = "videos"
+	ClassAuth   EndpointClass = "auth"
+	ClassOther  EndpointClass = ""
+)
+
+func classOf(path string) EndpointClass {
+	switch {
+	case strings.HasPrefix(path, "/api/v1/search"):
+		return ClassSearch
+	case strings.HasPrefix(path, "/api/v1/videos/"):
+		return ClassVideos
+	case strings.HasPrefix(path, "/api/v1/auth/"):
+		return ClassAuth
+	default:
+		return ClassOther
+	}
+}
+
+type RateLimit struct {
+	Rate  float64 // requests per second
+	Burst int     // default: 1
+}
+
+// RateLimiter is a token-bucket limiter that may be shared by several
+// clients. Every instance has its own bucket, and requests of a class listed
+// in Classes also wait on a bucket of the class. The rate of a bucket is
+// halved on every 429 response and recovers gradually afterwards.
+type RateLimiter struct {
+	Default   RateLimit
+	Instances map[string]RateLimit // by instance URL; overrides Default
+	Classes   map[EndpointClass]RateLimit
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{Default: RateLimit{Rate: rate, Burst: burst}}
+}
+
+// wait blocks until a request to path of the instance may be sent.
+func (rl *RateLimiter) wait(ctx context.Context, instanceURL, path string) error {
+	for _, b := range rl.bucketsOf(instanceURL, path) {
+		if err := b.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// observe adapts the buckets used by a request to its response.
+func (rl *RateLimiter) observe(instanceURL, path string, resp *http.Response) {
+	var retryAfter time.Duration
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && sec > 0 {
+			retryAfter = time.Duration(sec) * time.Second
+		}
+	}
+	for _, b := range rl.bucketsOf(instanceURL, path) {
+		if resp.StatusCode == http.StatusTooManyRequests {
+			b.slowDown(retryAfter)
+		} else {
+			b.recover()
+		}
+	}
+}
+
+func (rl *RateLimiter) bucketsOf(instanceURL, path string) []*bucket {
+	instanceURL = strings.TrimSuffix(instanceURL, "/")
+	limit, ok := rl.Instances[instanceURL]
+	if !ok {
+		limit = rl.Default
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if rl.buckets == nil {
+		rl.buckets = make(map[string]*bucket)
+	}
+	buckets := make([]*bucket, 0, 2)
+	if limit.Rate > 0 {
+		buckets = append(buckets, rl.bucket(instanceURL, limit))
+	}
+	class := classOf(path)
+	if limit, ok := rl.Classes[class]; ok && class != ClassOther && limit.Rate > 0 {
+		buckets = append(buckets, rl.bucket(instanceURL+"#"+string(class), limit))
+	}
+	return buckets
+}
+
+func (rl *RateLimiter) bucket(key string, limit RateLimit) *bucket {
+	b, ok := rl.buckets[key]
+	if !ok {
+		burst := float64(max(limit.Burst, 1))
+		b = &bucket{limit: limit.Rate, rate: limit.Rate, burst: burst, tokens: burst, last: time.Now()}
+		rl.buckets[key] = b
+	}
+	return b
+}
+
+type bucket struct {
+	mu     sync.Mutex
+	limit  float64 // configured rate
+	rate   float64 // current rate
+	burst  float64
+	tokens float64 // negative when requests are waiting
+	last   time.Time
+	until  time.Time // no requests before, set by Retry-After
+}
+
+func (b *bucket) advance(now time.Time) {
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.advance(now)
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	delay = max(delay, b.until.Sub(now))
+	if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+		b.tokens++
+		b.mu.Unlock()
+		return context.DeadlineExceeded
+	}
+	b.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (b *bucket) slowDown(retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.advance(now)
+	b.rate = max(b.rate/2, b.limit/16)
+	if retryAfter > 0 {
+		b.until = now.Add(retryAfter)
+	}
+}
+
+func (b *bucket) recover() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rate < b.limit {
+		b.advance(time.Now())
+		b.rate = min(b.rate+b.limit/32, b.limit)
+	}
+}
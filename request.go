@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// Request describes a call to an endpoint that isn't wrapped by Client.
+type Request struct {
+	Operation string // passed to the interceptors; default: "Do"
+	Method    string // default: "GET"
+	Path      string // e.g. "/api/v1/clips/" + id
+	Query     url.Values
+	Header    http.Header // requests with headers are neither coalesced nor cached
+	Body      any         // encoded as JSON if non-nil
+	Auth      bool
+}
+
+func (r *Request) config(output any) *requestConfig {
+	op, method := r.Operation, r.Method
+	if op == "" {
+		op = "Do"
+	}
+	if method == "" {
+		method = "GET"
+	}
+	return &requestConfig{
+		Operation: op,
+		Method:    method,
+		Path:      r.Path,
+		Auth:      r.Auth,
+		Query:     r.Query,
+		Header:    r.Header,
+		Input:     r.Body,
+		Output:    output,
+	}
+}
+
+// Do sends req like the methods of c do and decodes the JSON response into
+// out, unless out is nil.
+func (c *Client) Do(ctx context.Context, req Request, out any) error {
+	return c.WithContext(ctx).call(req.config(out))
+}
+
+// Call is like Do, but returns the decoded response.
+func Call[T any](ctx context.Context, c *Client, req Request) (*T, error) {
+	var v T
+	if err := c.Do(ctx, req, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+	URL        *url.URL      // after redirects
+	Latency    time.Duration // until the response headers were received
+	Cached     bool          // served by the ResponseCache of the Client
+}
+
+// WithResponseMeta returns a shallow copy of c that stores the metadata of
+// the last response of its requests in meta. Cached responses only have their
+// status code and headers. The copy must not send requests concurrently; see
+// DoRaw for the metadata of a single request. Methods that send requests
+// concurrently, e.g. VideosBatch, don't store their metadata.
+func (c *Client) WithResponseMeta(meta *ResponseMeta) *Client {
+	c2 := *c
+	c2.meta = meta
+	return &c2
+}
+
+// DoRaw sends req and returns the undecoded response. Value is empty if the
+// response has no body.
+func (c *Client) DoRaw(ctx context.Context, req Request) (jsontext.Value, *ResponseMeta, error) {
+	var (
+		out  rawOutput
+		meta ResponseMeta
+	)
+	c = c.WithContext(ctx)
+	c.meta = &meta
+	if err := c.call(req.config(&out)); err != nil {
+		if meta.StatusCode == 0 {
+			return nil, nil, err
+		}
+		return nil, &meta, err
+	}
+	return out.value, &meta, nil
+}
+
+type rawOutput struct {
+	value jsontext.Value
+}
+
+func (o *rawOutput) decodeResponse(resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotModified {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || len(body) == 0 {
+		return err
+	}
+	value := jsontext.Value(body)
+	if !value.IsValid() {
+		return errors.New("invalid JSON response")
+	}
+	o.value = value
+	return nil
+}
+
+func (m *ResponseMeta) record(resp *http.Response, start time.Time) {
+	*m = ResponseMeta{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		URL:        resp.Request.URL,
+		Latency:    time.Since(start),
+	}
+}
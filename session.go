@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"errors"
+	"html"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const sessionCookie = "SID"
+
+var ErrLoginFailed = errors.New("login failed: no session cookie received")
+
+// Login signs in with the Invidious login form and stores the resulting SID
+// cookie in the jar of HTTPClient. If HTTPClient has no jar, it's replaced by
+// a copy with its own jar, so http.DefaultClient and shared clients are never
+// modified. Authenticated calls then use the cookie whenever RawToken is
+// empty.
+//
+// Login must be called on the long-lived Client rather than on a copy made by
+// WithContext and similar methods, since copies made before Login don't see
+// the new jar.
+func (c *Client) Login(username, password string) error {
+	if err := c.ensureJar(); err != nil {
+		return err
+	}
+
+	form := make(url.Values, 4)
+	form.Set("email", username)
+	form.Set("password", password)
+	form.Set("action", "signin")
+	csrfToken, err := c.csrfToken("Login", "/login")
+	if err != nil {
+		return err
+	}
+	if csrfToken != "" {
+		form.Set("csrf_token", csrfToken)
+	}
+
+	query := make(url.Values, 2)
+	query.Set("referer", "/")
+	query.Set("type", "invidious")
+	resp, err := c.postForm("Login", "/login?"+query.Encode(), form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == sessionCookie && cookie.Value != "" {
+			return nil
+		}
+	}
+	if resp.StatusCode >= 400 {
+		return newError(resp)
+	}
+	return ErrLoginFailed
+}
+
+// Logout signs out of the session created by Login.
+func (c *Client) Logout() error {
+	if c.SessionID() == "" {
+		return nil
+	}
+	csrfToken, err := c.csrfToken("Logout", "/preferences")
+	if err != nil {
+		return err
+	}
+	form := make(url.Values, 1)
+	form.Set("csrf_token", csrfToken)
+	resp, err := c.postForm("Logout", "/signout?referer=/", form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode >= 400 {
+		return newError(resp)
+	}
+
+	if u, err := url.Parse(c.InstanceURL); err == nil {
+		c.HTTPClient.Jar.SetCookies(u, []*http.Cookie{
+			{Name: sessionCookie, Path: "/", MaxAge: -1},
+		})
+	}
+	return nil
+}
+
+// SessionID returns the SID cookie stored by Login, or "" if there is none.
+// Sessions of the account, including this one, are listed by Tokens.
+func (c *Client) SessionID() string {
+	if c.HTTPClient == nil || c.HTTPClient.Jar == nil {
+		return ""
+	}
+	u, err := url.Parse(c.InstanceURL)
+	if err != nil {
+		return ""
+	}
+	for _, cookie := range c.HTTPClient.Jar.Cookies(u) {
+		if cookie.Name == sessionCookie {
+			return cookie.Value
+		}
+	}
+	return ""
+}
+
+func (c *Client) ensureJar() error {
+	if c.HTTPClient != nil && c.HTTPClient.Jar != nil {
+		return nil
+	}
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return err
+	}
+	hc := *c.httpClient()
+	hc.Jar = jar
+	c.HTTPClient = &hc
+	return nil
+}
+
+var reCSRFToken = regexp.MustCompile(`name="csrf_token"\s+value="([^"]*)"`)
+
+func (c *Client) csrfToken(op, path string) (string, error) {
+	req, err := c.newRequest("GET", path, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.roundTrip(op, req, c.httpClient())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", newError(resp)
+	}
+	page, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	m := reCSRFToken.FindSubmatch(page)
+	if m == nil {
+		return "", nil
+	}
+	token := html.UnescapeString(bytes2string(m[1]))
+	if unescaped, err := url.QueryUnescape(token); err == nil {
+		token = unescaped
+	}
+	return token, nil
+}
+
+// postForm doesn't follow redirects, so that the Set-Cookie headers of the
+// response can be inspected.
+func (c *Client) postForm(op, path string, form url.Values) (*http.Response, error) {
+	req, err := c.newRequest("POST", path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	hc := *c.httpClient()
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return c.roundTrip(op, req, &hc)
+}
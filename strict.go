@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/antoniszymanski/option-go"
+	"github.com/go-json-experiment/json"
+)
+
+// SchemaDrift describes the differences between a JSON response and the Go
+// type it's decoded into. Members are written as paths such as
+// "adaptiveFormats[].fps".
+type SchemaDrift struct {
+	Operation string
+	Type      string   // e.g. "invidious.VideoResponse"
+	Unknown   []string // members without a Go field
+	Missing   []string // non-optional fields absent from the response
+}
+
+func (d *SchemaDrift) IsEmpty() bool {
+	return len(d.Unknown) == 0 && len(d.Missing) == 0
+}
+
+// DetectDrift compares the JSON value data with the type of v. Fields of
+// type Option, pointers and maps are optional.
+func DetectDrift(data []byte, v any) (SchemaDrift, error) {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return SchemaDrift{}, err
+	}
+	w := driftWalker{unknown: make(map[string]struct{}), missing: make(map[string]struct{})}
+	w.walk(t, value, "")
+	return SchemaDrift{
+		Type:    t.String(),
+		Unknown: slices.Sorted(maps.Keys(w.unknown)),
+		Missing: slices.Sorted(maps.Keys(w.missing)),
+	}, nil
+}
+
+type driftWalker struct {
+	unknown map[string]struct{}
+	missing map[string]struct{}
+}
+
+func (w *driftWalker) walk(t reflect.Type, value any, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if decodesItself(t) {
+		return
+	}
+	switch value := value.(type) {
+	case map[string]any:
+		switch t.Kind() {
+		case reflect.Map:
+			for _, v := range value {
+				w.walk(t.Elem(), v, memberPath(path, "*"))
+			}
+		case reflect.Struct:
+			members := membersOf(t)
+			for _, m := range members {
+				v, ok := value[m.name]
+				if !ok {
+					if !isOptional(m.typ) {
+						w.missing[memberPath(path, m.name)] = struct{}{}
+					}
+					continue
+				}
+				w.walk(m.typ, v, memberPath(path, m.name))
+			}
+			for name := range value {
+				if !slices.ContainsFunc(members, func(m member) bool { return m.name == name }) {
+					w.unknown[memberPath(path, name)] = struct{}{}
+				}
+			}
+		}
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, v := range value {
+				w.walk(t.Elem(), v, path+"[]")
+			}
+		}
+	}
+}
+
+func memberPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func isOptional(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Interface:
+		return true
+	}
+	return option.IsOption(t)
+}
+
+// unmarshal decodes the JSON response of config, reporting its drift if
+// StrictDecoding is enabled.
+func (c *Client) unmarshal(config *requestConfig, data []byte) error {
+	if err := json.Unmarshal(data, config.Output, opts); err != nil {
+		return err
+	}
+	if !c.StrictDecoding || c.OnSchemaDrift == nil {
+		return nil
+	}
+	drift, err := DetectDrift(data, config.Output)
+	// Partial responses lack the members that weren't requested.
+	if c.fields != "" {
+		drift.Missing = nil
+	}
+	if err == nil && !drift.IsEmpty() {
+		drift.Operation = config.Operation
+		c.OnSchemaDrift(drift)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	"github.com/go-json-experiment/json"
+)
+
+type freeTubeProfile struct {
+	Name          string `json:"name"`
+	BgColor       string `json:"bgColor"`
+	TextColor     string `json:"textColor"`
+	Subscriptions []struct {
+		Id        string `json:"id"`
+		Name      string `json:"name"`
+		Thumbnail string `json:"thumbnail"`
+	} `json:"subscriptions"`
+	Id      string `json:"_id"`
+	Deleted bool   `json:"$$deleted,omitzero"`
+}
+
+// ReadFreeTube reads the profiles of a FreeTube profiles.db file and returns
+// the union of their subscriptions.
+//
+// The file is an append-only NeDB database: only the last record of a profile
+// is current, and deleted profiles end with a "$$deleted" record.
+func ReadFreeTube(r io.Reader) ([]Subscription, error) {
+	var ids []string // in order of appearance
+	profiles := make(map[string]*freeTubeProfile)
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 64<<20)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		profile := new(freeTubeProfile)
+		if err := json.Unmarshal(line, profile); err != nil {
+			return nil, err
+		}
+		if profile.Id == "" {
+			continue // e.g. an index definition
+		}
+		if _, ok := profiles[profile.Id]; !ok {
+			ids = append(ids, profile.Id)
+		}
+		profiles[profile.Id] = profile
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	var subs []Subscription
+	for _, id := range ids {
+		if profile := profiles[id]; !profile.Deleted {
+			for _, s := range profile.Subscriptions {
+				subs = append(subs, Subscription{ChannelId: s.Id, Name: s.Name})
+			}
+		}
+	}
+	return dedupe(subs), nil
+}
+
+// WriteFreeTube writes a profiles.db file with the default "All Channels"
+// profile.
+func WriteFreeTube(w io.Writer, subs []Subscription) error {
+	profile := freeTubeProfile{
+		Name:      "All Channels",
+		BgColor:   "#000000",
+		TextColor: "#FFFFFF",
+		Id:        "allChannels",
+	}
+	profile.Subscriptions = make([]struct {
+		Id        string `json:"id"`
+		Name      string `json:"name"`
+		Thumbnail string `json:"thumbnail"`
+	}, len(subs))
+	for i, s := range subs {
+		profile.Subscriptions[i].Id = s.ChannelId
+		profile.Subscriptions[i].Name = s.Name
+	}
+	if err := json.MarshalWrite(w, &profile); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
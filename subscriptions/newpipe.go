@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"io"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+const newPipeYouTube = 0
+
+type newPipeExport struct {
+	AppVersion    string `json:"app_version"`
+	AppVersionInt int32  `json:"app_version_int"`
+	Subscriptions []struct {
+		ServiceId int32  `json:"service_id"`
+		Url       string `json:"url"`
+		Name      string `json:"name"`
+	} `json:"subscriptions"`
+}
+
+func ReadNewPipe(r io.Reader) ([]Subscription, error) {
+	var export newPipeExport
+	if err := json.UnmarshalRead(r, &export); err != nil {
+		return nil, err
+	}
+	subs := make([]Subscription, 0, len(export.Subscriptions))
+	for _, s := range export.Subscriptions {
+		if s.ServiceId != newPipeYouTube {
+			continue
+		}
+		subs = append(subs, Subscription{ChannelId: channelIdFromURL(s.Url), Name: s.Name})
+	}
+	return dedupe(subs), nil
+}
+
+func WriteNewPipe(w io.Writer, subs []Subscription) error {
+	export := newPipeExport{AppVersion: "0.27.6", AppVersionInt: 1001}
+	export.Subscriptions = make([]struct {
+		ServiceId int32  `json:"service_id"`
+		Url       string `json:"url"`
+		Name      string `json:"name"`
+	}, len(subs))
+	for i, s := range subs {
+		export.Subscriptions[i].ServiceId = newPipeYouTube
+		export.Subscriptions[i].Url = channelURL(s.ChannelId)
+		export.Subscriptions[i].Name = s.Name
+	}
+	return json.MarshalWrite(w, &export, jsontext.Multiline(true))
+}
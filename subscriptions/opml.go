@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+type opmlDocument struct {
+	XMLName xml.Name      `xml:"opml"`
+	Version string        `xml:"version,attr"`
+	Body    []opmlOutline `xml:"body>outline"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+func ReadOPML(r io.Reader) ([]Subscription, error) {
+	var doc opmlDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+	var subs []Subscription
+	var walk func([]opmlOutline)
+	walk = func(outlines []opmlOutline) {
+		for _, o := range outlines {
+			if id := channelIdFromURL(o.XMLURL); id != "" {
+				name := o.Title
+				if name == "" {
+					name = o.Text
+				}
+				subs = append(subs, Subscription{ChannelId: id, Name: name})
+			}
+			walk(o.Outlines)
+		}
+	}
+	walk(doc.Body)
+	return dedupe(subs), nil
+}
+
+func WriteOPML(w io.Writer, subs []Subscription) error {
+	group := opmlOutline{
+		Text:     "YouTube Subscriptions",
+		Title:    "YouTube Subscriptions",
+		Outlines: make([]opmlOutline, len(subs)),
+	}
+	for i, s := range subs {
+		group.Outlines[i] = opmlOutline{
+			Text:   s.Name,
+			Title:  s.Name,
+			Type:   "rss",
+			XMLURL: "https://www.youtube.com/feeds/videos.xml?channel_id=" + s.ChannelId,
+		}
+	}
+	doc := opmlDocument{Version: "1.1", Body: []opmlOutline{group}}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+// Package subscriptions converts subscription lists between Invidious,
+// NewPipe, FreeTube and YouTube.
+package subscriptions
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+type Subscription struct {
+	ChannelId string
+	Name      string
+}
+
+type Format uint8
+
+const (
+	OPML     Format = iota + 1 // Invidious and YouTube OPML export
+	NewPipe                    // NewPipe JSON export
+	FreeTube                   // FreeTube profiles.db (JSON lines)
+	Takeout                    // Google Takeout subscriptions.csv
+)
+
+func (f Format) String() string {
+	switch f {
+	case OPML:
+		return "OPML"
+	case NewPipe:
+		return "NewPipe"
+	case FreeTube:
+		return "FreeTube"
+	case Takeout:
+		return "Takeout"
+	default:
+		return "Format(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+var ErrUnknownFormat = errors.New("unknown subscription format")
+
+// FormatOf guesses the format from the extension of a file name.
+func FormatOf(name string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".opml", ".xml":
+		return OPML, nil
+	case ".json":
+		return NewPipe, nil
+	case ".db":
+		return FreeTube, nil
+	case ".csv":
+		return Takeout, nil
+	default:
+		return 0, ErrUnknownFormat
+	}
+}
+
+func Read(r io.Reader, f Format) ([]Subscription, error) {
+	switch f {
+	case OPML:
+		return ReadOPML(r)
+	case NewPipe:
+		return ReadNewPipe(r)
+	case FreeTube:
+		return ReadFreeTube(r)
+	case Takeout:
+		return ReadTakeout(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func Write(w io.Writer, f Format, subs []Subscription) error {
+	switch f {
+	case OPML:
+		return WriteOPML(w, subs)
+	case NewPipe:
+		return WriteNewPipe(w, subs)
+	case FreeTube:
+		return WriteFreeTube(w, subs)
+	case Takeout:
+		return WriteTakeout(w, subs)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// FromResponse converts the result of Client.Subscriptions.
+func FromResponse(resp invidious.SubscriptionsResponse) []Subscription {
+	subs := make([]Subscription, len(resp))
+	for i, s := range resp {
+		subs[i] = Subscription{ChannelId: s.AuthorId, Name: s.Author}
+	}
+	return subs
+}
+
+func channelURL(id string) string {
+	return "https://www.youtube.com/channel/" + id
+}
+
+// channelIdFromURL extracts the channel ID from a channel or feed URL.
+func channelIdFromURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+	if id := u.Query().Get("channel_id"); id != "" {
+		return id
+	}
+	if _, id, ok := strings.Cut(u.Path, "/channel/"); ok {
+		id, _, _ = strings.Cut(id, "/")
+		return id
+	}
+	return ""
+}
+
+func dedupe(subs []Subscription) []Subscription {
+	seen := make(map[string]struct{}, len(subs))
+	out := subs[:0]
+	for _, s := range subs {
+		if _, ok := seen[s.ChannelId]; ok || s.ChannelId == "" {
+			continue
+		}
+		seen[s.ChannelId] = struct{}{}
+		out = append(out, s)
+	}
+	return out
+}
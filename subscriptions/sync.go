@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"errors"
+
+	"github.com/antoniszymanski/invidious-go"
+)
+
+// ErrEmptyDesired is returned by Sync when the desired list is empty, which
+// usually means that it couldn't be read, unless AllowEmpty is set.
+var ErrEmptyDesired = errors.New("desired subscription list is empty")
+
+type SyncOptions struct {
+	DryRun     bool // report the changes without applying them
+	KeepExtras bool // don't remove subscriptions missing from the desired list
+	AllowEmpty bool // allow an empty desired list to remove every subscription
+}
+
+type SyncReport struct {
+	Added   []Subscription // in dry-run mode, the changes that would be applied
+	Removed []Subscription
+	Failed  []Subscription
+}
+
+// Sync makes the subscriptions of the authenticated user equal to desired,
+// using the minimal set of AddSubscription and RemoveSubscription calls.
+// The returned error joins the errors of the failed calls.
+func Sync(c *invidious.Client, desired []Subscription, opts SyncOptions) (*SyncReport, error) {
+	if len(desired) == 0 && !opts.KeepExtras && !opts.AllowEmpty {
+		return nil, ErrEmptyDesired
+	}
+	resp, err := c.Subscriptions()
+	if err != nil {
+		return nil, err
+	}
+	current := FromResponse(resp)
+
+	have := make(map[string]struct{}, len(current))
+	for _, s := range current {
+		have[s.ChannelId] = struct{}{}
+	}
+	want := make(map[string]struct{}, len(desired))
+	var toAdd, toRemove []Subscription
+	for _, s := range dedupe(append([]Subscription(nil), desired...)) {
+		want[s.ChannelId] = struct{}{}
+		if _, ok := have[s.ChannelId]; !ok {
+			toAdd = append(toAdd, s)
+		}
+	}
+	if !opts.KeepExtras {
+		for _, s := range current {
+			if _, ok := want[s.ChannelId]; !ok {
+				toRemove = append(toRemove, s)
+			}
+		}
+	}
+
+	if opts.DryRun {
+		return &SyncReport{Added: toAdd, Removed: toRemove}, nil
+	}
+	var report SyncReport
+	var errs []error
+	for _, s := range toAdd {
+		if err = c.AddSubscription(s.ChannelId); err != nil {
+			report.Failed = append(report.Failed, s)
+			errs = append(errs, err)
+		} else {
+			report.Added = append(report.Added, s)
+		}
+	}
+	for _, s := range toRemove {
+		if err = c.RemoveSubscription(s.ChannelId); err != nil {
+			report.Failed = append(report.Failed, s)
+			errs = append(errs, err)
+		} else {
+			report.Removed = append(report.Removed, s)
+		}
+	}
+	return &report, errors.Join(errs...)
+}
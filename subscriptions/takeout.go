@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package subscriptions
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+var takeoutHeader = []string{"Channel Id", "Channel Url", "Channel Title"}
+
+func ReadTakeout(r io.Reader) ([]Subscription, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	if !strings.EqualFold(strings.TrimPrefix(records[0][0], "\ufeff"), takeoutHeader[0]) {
+		return nil, errors.New("invalid Takeout CSV header")
+	}
+	subs := make([]Subscription, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		if len(rec) < 3 || rec[0] == "" {
+			continue
+		}
+		subs = append(subs, Subscription{ChannelId: rec[0], Name: rec[2]})
+	}
+	return dedupe(subs), nil
+}
+
+func WriteTakeout(w io.Writer, subs []Subscription) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(takeoutHeader); err != nil {
+		return err
+	}
+	for _, s := range subs {
+		if err := cw.Write([]string{
+			s.ChannelId,
+			"http://www.youtube.com/channel/" + s.ChannelId,
+			s.Name,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/antoniszymanski/option-go"
+)
+
+// RevokePolicy selects the sessions revoked by RevokeSessions. A session is
+// revoked unless it is the current one, is kept by Keep, or is newer than
+// OlderThan. The zero value revokes every other session.
+type RevokePolicy struct {
+	OlderThan      option.Option[time.Duration]
+	IncludeCurrent bool     // revoke the current session too
+	Keep           []string // allowlist of sessions
+	DryRun         bool     // report the matching sessions without revoking them
+	Concurrency    int      // default: 4
+}
+
+type RevokeReport struct {
+	Revoked []Session // in dry-run mode, the sessions that would be revoked
+	Kept    []Session
+	Failed  []Session
+}
+
+// RevokeSessions revokes, in parallel, every session matching the policy.
+// The current session, which authenticates the revocations, is revoked last.
+// The returned error joins the errors of the failed revocations.
+func (c *Client) RevokeSessions(policy RevokePolicy) (*RevokeReport, error) {
+	sessions, err := c.Tokens()
+	if err != nil {
+		return nil, err
+	}
+
+	current := c.currentSession()
+	if current == "" && !policy.IncludeCurrent {
+		return nil, errors.New("current session is unknown")
+	}
+	now := time.Now()
+	var report RevokeReport
+	var targets []Session
+	var last option.Option[Session]
+	for _, s := range sessions {
+		switch {
+		case s.Session == current && !policy.IncludeCurrent,
+			slices.Contains(policy.Keep, s.Session),
+			policy.OlderThan.IsSomeAnd(func(d time.Duration) bool {
+				return now.Sub(s.Issued) <= d
+			}):
+			report.Kept = append(report.Kept, s)
+		case s.Session == current:
+			last = option.Some(s)
+		default:
+			targets = append(targets, s)
+		}
+	}
+	if policy.DryRun {
+		report.Revoked = targets
+		last.Inspect(func(s *Session) { report.Revoked = append(report.Revoked, *s) })
+		return &report, nil
+	}
+
+	c = c.WithResponseMeta(nil) // the revocations run concurrently
+	concurrency := policy.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+		sem  = make(chan struct{}, concurrency)
+	)
+	revoke := func(s Session) {
+		err := c.RevokeToken(RevokeRequest{Session: s.Session})
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			report.Failed = append(report.Failed, s)
+			errs = append(errs, err)
+		} else {
+			report.Revoked = append(report.Revoked, s)
+		}
+	}
+	for _, s := range targets {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			revoke(s)
+		})
+	}
+	wg.Wait()
+	last.Inspect(func(s *Session) { revoke(*s) })
+	return &report, errors.Join(errs...)
+}
+
+func (c *Client) currentSession() string {
+	if c.RawToken != "" {
+		if t, err := ParseToken(c.RawToken); err == nil {
+			return t.Session
+		}
+		return ""
+	}
+	return c.SessionID()
+}
@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"time"
+
+	. "github.com/antoniszymanski/option-go"
+	"github.com/go-json-experiment/json"
+)
+
+// FeedWatcher polls the feed of the authenticated user and emits an event
+// for every video published after the persisted high-water mark.
+type FeedWatcher struct {
+	Client   *Client
+	Interval time.Duration // default: 5 minutes
+	Jitter   time.Duration // random delay added to every interval
+	MaxPages int32         // pages fetched per poll (default: 5)
+	Store    WatermarkStore
+
+	// Events and OnEvent receive the new videos, oldest first.
+	Events  chan<- NewVideoEvent
+	OnEvent func(NewVideoEvent)
+	// OnError receives polling errors. If nil, Run returns on the first error.
+	OnError func(error)
+	// EmitInitial emits the current feed when there is no high-water mark yet.
+	EmitInitial bool
+}
+
+type NewVideoEvent struct {
+	Video        FeedVideo
+	Published    time.Time
+	Notification bool // whether the video comes from the notifications array
+}
+
+// HighWaterMark identifies the newest videos that have already been emitted.
+type HighWaterMark struct {
+	Published time.Time `json:"published"`
+	VideoIds  []string  `json:"videoIds"` // videos published at Published
+}
+
+func (m HighWaterMark) IsZero() bool {
+	return m.Published.IsZero() && len(m.VideoIds) == 0
+}
+
+// advance returns the mark after e was emitted.
+func (m HighWaterMark) advance(e NewVideoEvent) HighWaterMark {
+	switch {
+	case e.Published.After(m.Published):
+		return HighWaterMark{Published: e.Published, VideoIds: []string{e.Video.VideoId}}
+	case e.Published.Equal(m.Published) && !slices.Contains(m.VideoIds, e.Video.VideoId):
+		m.VideoIds = append(slices.Clip(m.VideoIds), e.Video.VideoId)
+	}
+	return m
+}
+
+func (m HighWaterMark) covers(videoId string, published time.Time) bool {
+	return published.Before(m.Published) ||
+		published.Equal(m.Published) && slices.Contains(m.VideoIds, videoId)
+}
+
+type WatermarkStore interface {
+	Load() (HighWaterMark, error)
+	Save(HighWaterMark) error
+}
+
+// FileWatermarkStore persists the high-water mark as JSON in the named file.
+type FileWatermarkStore string
+
+func (name FileWatermarkStore) Load() (m HighWaterMark, err error) {
+	data, err := os.ReadFile(string(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	} else if err != nil {
+		return m, err
+	}
+	err = json.Unmarshal(data, &m, opts)
+	return
+}
+
+func (name FileWatermarkStore) Save(m HighWaterMark) error {
+	data, err := json.Marshal(&m, opts)
+	if err != nil {
+		return err
+	}
+	tmp := string(name) + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, string(name))
+}
+
+// MemoryWatermarkStore keeps the high-water mark in memory.
+type MemoryWatermarkStore struct {
+	Mark HighWaterMark
+}
+
+func (s *MemoryWatermarkStore) Load() (HighWaterMark, error) { return s.Mark, nil }
+
+func (s *MemoryWatermarkStore) Save(m HighWaterMark) error {
+	s.Mark = m
+	return nil
+}
+
+// Run polls until ctx is done.
+func (w *FeedWatcher) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+	for {
+		if _, err := w.Poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.OnError == nil {
+				return err
+			}
+			w.OnError(err)
+		}
+
+		delay := interval
+		if w.Jitter > 0 {
+			delay += rand.N(w.Jitter)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Poll fetches the feed once, emits the new videos and advances the
+// high-water mark past every emitted video. On error, it returns the videos
+// emitted before the error.
+func (w *FeedWatcher) Poll(ctx context.Context) ([]NewVideoEvent, error) {
+	store := w.Store
+	if store == nil {
+		store = &MemoryWatermarkStore{}
+		w.Store = store
+	}
+	mark, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	events, err := w.fetch(ctx, mark)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	if mark.IsZero() && !w.EmitInitial {
+		for _, e := range events {
+			mark = mark.advance(e)
+		}
+		return nil, store.Save(mark)
+	}
+	// The mark is saved after every event, so that the events delivered
+	// before a shutdown aren't emitted again after a restart.
+	for i, e := range events {
+		if err = w.emit(ctx, e); err != nil {
+			return events[:i], err
+		}
+		mark = mark.advance(e)
+		if err = store.Save(mark); err != nil {
+			return events[:i+1], err
+		}
+	}
+	return events, nil
+}
+
+func (w *FeedWatcher) fetch(ctx context.Context, mark HighWaterMark) ([]NewVideoEvent, error) {
+	maxPages := w.MaxPages
+	if maxPages <= 0 {
+		maxPages = 5
+	}
+	if mark.IsZero() {
+		maxPages = 1
+	}
+	c := w.Client.WithContext(ctx)
+
+	var events []NewVideoEvent
+	seen := make(map[string]struct{})
+	add := func(v FeedVideo, notification bool) bool {
+		published := time.Unix(v.Published, 0)
+		if _, ok := seen[v.VideoId]; ok || mark.covers(v.VideoId, published) {
+			return false
+		}
+		seen[v.VideoId] = struct{}{}
+		events = append(events, NewVideoEvent{
+			Video:        v,
+			Published:    published,
+			Notification: notification,
+		})
+		return true
+	}
+	for page := int32(1); page <= maxPages; page++ {
+		resp, err := c.Feed(FeedRequest{Page: Some(page)})
+		if err != nil {
+			return nil, err
+		}
+		if page == 1 {
+			for _, v := range resp.Notifications {
+				add(v, true)
+			}
+		}
+		if len(resp.Videos) == 0 {
+			break
+		}
+		reachedMark := false
+		for _, v := range resp.Videos {
+			if !add(v, false) && mark.covers(v.VideoId, time.Unix(v.Published, 0)) {
+				reachedMark = true
+			}
+		}
+		if reachedMark {
+			break
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b NewVideoEvent) int {
+		return a.Published.Compare(b.Published)
+	})
+	return events, nil
+}
+
+// emit delivers e to Events, then to OnEvent. Neither receives it if ctx is
+// done before Events does.
+func (w *FeedWatcher) emit(ctx context.Context, e NewVideoEvent) error {
+	if w.Events != nil {
+		select {
+		case w.Events <- e:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if w.OnEvent != nil {
+		w.OnEvent(e)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2025 Antoni Szymański
+// SPDX-License-Identifier: MPL-2.0
+
+package invidious_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/antoniszymanski/invidious-go"
+	"github.com/antoniszymanski/invidious-go/invidioustest"
+)
+
+func TestFeedWatcherRestart(t *testing.T) {
+	s := invidioustest.NewServer()
+	defer s.Close()
+	start := time.Unix(1_700_000_000, 0)
+	// Newest first, like Invidious.
+	for i, id := range []string{"v3", "v2", "v1"} {
+		s.Feed = append(s.Feed, invidious.FeedVideo{
+			VideoId:   id,
+			Published: start.Add(time.Duration(3-i) * time.Hour).Unix(),
+		})
+	}
+	store := &invidious.MemoryWatermarkStore{
+		Mark: invidious.HighWaterMark{Published: start},
+	}
+	var delivered []string
+	onEvent := func(e invidious.NewVideoEvent) { delivered = append(delivered, e.Video.VideoId) }
+
+	// The consumer shuts down after the first event.
+	ctx, cancel := context.WithCancel(t.Context())
+	events := make(chan invidious.NewVideoEvent)
+	go func() {
+		<-events
+		cancel()
+	}()
+	w := &invidious.FeedWatcher{Client: s.Client(), Store: store, Events: events, OnEvent: onEvent}
+	emitted, err := w.Poll(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Poll error = %v, want context.Canceled", err)
+	}
+	if len(emitted) != 1 {
+		t.Fatalf("Poll emitted %d events before the shutdown, want 1", len(emitted))
+	}
+
+	// After a restart, only the undelivered events are emitted.
+	w = &invidious.FeedWatcher{Client: s.Client(), Store: store, OnEvent: onEvent}
+	if _, err = w.Poll(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"v1", "v2", "v3"}; !slices.Equal(delivered, want) {
+		t.Errorf("delivered = %v, want %v", delivered, want)
+	}
+}